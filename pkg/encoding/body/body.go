@@ -0,0 +1,7 @@
+// Package body provides functions for parsing and building the bodies of SIP messages, such as the multipart MIME
+// bodies defined in RFC 2046 section 5.1 and allowed by RFC 3261 section 7.4.
+//
+// Importing this package registers its codecs with message.RegisterBodyCodec, so that Message.DecodeBody and
+// Message.SetBody handle multipart, application/sdp, application/pidf+xml, application/dtmf-relay and
+// application/simple-message-summary bodies.
+package body
@@ -0,0 +1,72 @@
+package body
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/otoru/party/pkg/encoding/message"
+)
+
+// The codecs of this package are registered for the message bodies they know, so that importing it is enough for
+// Message.DecodeBody and Message.SetBody to handle them.
+func init() {
+	message.RegisterBodyCodec("multipart/*", MultipartCodec{})
+	message.RegisterBodyCodec("application/pidf+xml", XMLCodec{})
+	message.RegisterBodyCodec("application/dtmf-relay", DTMFRelayCodec{})
+	message.RegisterBodyCodec("application/simple-message-summary", MessageSummaryCodec{})
+	message.RegisterBodyCodec("application/sdp", SessionDescriptionCodec{})
+}
+
+// MultipartCodec is the message.BodyCodec of multipart bodies, which are decoded into and encoded from a *Multipart.
+type MultipartCodec struct{}
+
+// Unmarshal parses a multipart body into v, which must be a *Multipart.
+func (MultipartCodec) Unmarshal(contentType string, payload []byte, v any) error {
+	body, ok := v.(*Multipart)
+
+	if !ok {
+		return fmt.Errorf("%w: cannot decode %s into %T", message.ErrUnsupportedBody, contentType, v)
+	}
+
+	return Unmarshal(contentType, payload, body)
+}
+
+// Marshal encodes v, which must be a *Multipart, returning the Content-Type with its boundary.
+func (MultipartCodec) Marshal(contentType string, v any) (string, []byte, error) {
+	body, ok := v.(*Multipart)
+
+	if !ok {
+		return "", nil, fmt.Errorf("%w: cannot encode %T as %s", message.ErrUnsupportedBody, v, contentType)
+	}
+
+	payload, err := Marshal(body)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return body.ContentType(), payload, nil
+}
+
+// XMLCodec is the message.BodyCodec of XML bodies, such as application/pidf+xml, which uses encoding/xml.
+type XMLCodec struct{}
+
+// Unmarshal parses an XML body into v.
+func (XMLCodec) Unmarshal(contentType string, payload []byte, v any) error {
+	if err := xml.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidBody, contentType, err)
+	}
+
+	return nil
+}
+
+// Marshal encodes v as an XML document, with its declaration.
+func (XMLCodec) Marshal(contentType string, v any) (string, []byte, error) {
+	payload, err := xml.Marshal(v)
+
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %s: %s", ErrInvalidBody, contentType, err)
+	}
+
+	return contentType, append([]byte(xml.Header), payload...), nil
+}
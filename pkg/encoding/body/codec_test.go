@@ -0,0 +1,286 @@
+package body
+
+import (
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/otoru/party/pkg/encoding/message"
+	"github.com/stretchr/testify/assert"
+)
+
+// presence is a minimal PIDF document, as defined in RFC 3863.
+type presence struct {
+	XMLName xml.Name `xml:"urn:ietf:params:xml:ns:pidf presence"`
+	Entity  string   `xml:"entity,attr"`
+	Status  string   `xml:"tuple>status>basic"`
+}
+
+func TestMessageBodyWithRegisteredCodecs(t *testing.T) {
+	table := []struct {
+		name        string
+		contentType string
+		value       any
+		payload     string
+		target      func() any
+	}{
+		{
+			name:        "dtmf-relay",
+			contentType: "application/dtmf-relay",
+			value:       &DTMFRelay{Signal: "5", Duration: 160},
+			payload:     "Signal=5\r\nDuration=160\r\n",
+			target:      func() any { return new(DTMFRelay) },
+		},
+		{
+			name:        "simple-message-summary",
+			contentType: "application/simple-message-summary",
+			value: &MessageSummary{
+				Waiting: true,
+				Account: "sip:alice@vmail.example.com",
+				Messages: map[string]MessageCount{
+					"Voice-Message": {New: 2, Old: 8, NewUrgent: 0, OldUrgent: 2},
+					"Fax-Message":   {New: 1, Old: 0},
+				},
+			},
+			payload: "Messages-Waiting: yes\r\n" +
+				"Message-Account: sip:alice@vmail.example.com\r\n" +
+				"Fax-Message: 1/0\r\n" +
+				"Voice-Message: 2/8 (0/2)\r\n",
+			target: func() any { return new(MessageSummary) },
+		},
+		{
+			name:        "sdp",
+			contentType: "application/sdp",
+			value: &SessionDescription{
+				Origin: Origin{
+					Username:       "alice",
+					SessionID:      "2890844526",
+					SessionVersion: "2890842807",
+					NetworkType:    "IN",
+					AddressType:    "IP4",
+					Address:        "192.0.2.101",
+				},
+				Name:       "-",
+				Connection: &Connection{NetworkType: "IN", AddressType: "IP4", Address: "192.0.2.101"},
+				Attributes: []Attribute{{Name: "sendrecv"}},
+				Media: []MediaDescription{
+					{
+						Type:       "audio",
+						Port:       49170,
+						Protocol:   "RTP/AVP",
+						Formats:    []string{"0", "101"},
+						Bandwidths: []Bandwidth{{Type: "AS", Value: 64}},
+						Attributes: []Attribute{{Name: "rtpmap", Value: "0 PCMU/8000"}, {Name: "rtpmap", Value: "101 telephone-event/8000"}},
+					},
+				},
+			},
+			payload: "v=0\r\n" +
+				"o=alice 2890844526 2890842807 IN IP4 192.0.2.101\r\n" +
+				"s=-\r\n" +
+				"c=IN IP4 192.0.2.101\r\n" +
+				"t=0 0\r\n" +
+				"a=sendrecv\r\n" +
+				"m=audio 49170 RTP/AVP 0 101\r\n" +
+				"b=AS:64\r\n" +
+				"a=rtpmap:0 PCMU/8000\r\n" +
+				"a=rtpmap:101 telephone-event/8000\r\n",
+			target: func() any { return new(SessionDescription) },
+		},
+		{
+			name:        "pidf+xml",
+			contentType: "application/pidf+xml",
+			value:       &presence{Entity: "pres:alice@example.com", Status: "open"},
+			payload: xml.Header +
+				"<presence xmlns=\"urn:ietf:params:xml:ns:pidf\" entity=\"pres:alice@example.com\">" +
+				"<tuple><status><basic>open</basic></status></tuple>" +
+				"</presence>",
+			target: func() any { return new(presence) },
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			sent := new(message.Message)
+
+			assert.Nil(t, sent.SetBody(tc.contentType, tc.value))
+
+			assert.Equal(t, tc.payload, string(sent.Body))
+			assert.Equal(t, tc.contentType, sent.Headers.Get("Content-Type"))
+			assert.Equal(t, fmt.Sprint(len(tc.payload)), sent.Headers.Get("Content-Length"))
+
+			got := tc.target()
+
+			assert.Nil(t, sent.DecodeBody(got))
+
+			if presence, ok := got.(*presence); ok {
+				presence.XMLName = xml.Name{}
+			}
+
+			assert.Equal(t, tc.value, got)
+		})
+	}
+}
+
+func TestMessageBodyWithMultipartCodec(t *testing.T) {
+	sent := new(message.Message)
+	body := NewMultipart("mixed", NewPart("application/sdp", []byte(sdp)), NewPart("application/pidf+xml", []byte("<presence/>")))
+
+	assert.Nil(t, sent.SetBody("multipart/mixed", body))
+
+	assert.Equal(t, body.ContentType(), sent.Headers.Get("Content-Type"))
+
+	got := new(Multipart)
+
+	assert.Nil(t, sent.DecodeBody(got))
+
+	assert.Equal(t, body, got)
+
+	assert.ErrorIs(t, sent.DecodeBody(new(DTMFRelay)), message.ErrUnsupportedBody)
+
+	assert.ErrorIs(t, sent.SetBody("multipart/mixed", &DTMFRelay{Signal: "1"}), message.ErrUnsupportedBody)
+}
+
+func TestDTMFRelayCodecWithInvalidBodies(t *testing.T) {
+	table := []struct {
+		name  string
+		input string
+	}{
+		{name: "missing signal", input: "Duration=160\r\n"},
+		{name: "invalid signal", input: "Signal=55\r\nDuration=160\r\n"},
+		{name: "invalid duration", input: "Signal=5\r\nDuration=long\r\n"},
+		{name: "negative duration", input: "Signal=5\r\nDuration=-1\r\n"},
+		{name: "malformed line", input: "Signal 5\r\n"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DTMFRelayCodec{}.Unmarshal("application/dtmf-relay", []byte(tc.input), new(DTMFRelay))
+
+			assert.ErrorIs(t, err, ErrInvalidBody)
+		})
+	}
+
+	_, _, err := DTMFRelayCodec{}.Marshal("application/dtmf-relay", DTMFRelay{Signal: "%"})
+
+	assert.ErrorIs(t, err, ErrInvalidBody)
+}
+
+func TestMessageSummaryCodec(t *testing.T) {
+	t.Run("accepts bare line feeds, any case and optional headers", func(t *testing.T) {
+		got := new(MessageSummary)
+		input := "messages-waiting: NO\nvoice-message: 0/3\n\nTo: <sip:alice@example.com>\n"
+
+		assert.Nil(t, MessageSummaryCodec{}.Unmarshal("application/simple-message-summary", []byte(input), got))
+
+		assert.Equal(t, &MessageSummary{Messages: map[string]MessageCount{"Voice-Message": {Old: 3}}}, got)
+	})
+
+	table := []struct {
+		name  string
+		input string
+	}{
+		{name: "missing Messages-Waiting", input: "Voice-Message: 2/8\r\n"},
+		{name: "invalid Messages-Waiting", input: "Messages-Waiting: maybe\r\n"},
+		{name: "malformed line", input: "Messages-Waiting yes\r\n"},
+		{name: "missing slash", input: "Messages-Waiting: yes\r\nVoice-Message: 2\r\n"},
+		{name: "negative count", input: "Messages-Waiting: yes\r\nVoice-Message: -2/8\r\n"},
+		{name: "unterminated urgent counts", input: "Messages-Waiting: yes\r\nVoice-Message: 2/8 (0/2\r\n"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			err := MessageSummaryCodec{}.Unmarshal("application/simple-message-summary", []byte(tc.input), new(MessageSummary))
+
+			assert.ErrorIs(t, err, ErrInvalidBody)
+		})
+	}
+}
+
+func TestSessionDescriptionCodec(t *testing.T) {
+	t.Run("accepts bare line feeds and keeps the lines out of the model", func(t *testing.T) {
+		got := new(SessionDescription)
+		input := "v=0\no=- 0 0 IN IP6 2001:db8::1\ns=Call\nu=http://www.example.com/\nt=0 0\nm=video 51372/2 RTP/AVP 31\nc=IN IP6 2001:db8::2\na=recvonly\n"
+
+		assert.Nil(t, SessionDescriptionCodec{}.Unmarshal("application/sdp", []byte(input), got))
+
+		assert.Equal(t, "Call", got.Name)
+		assert.Equal(t, []Line{{Type: 'u', Value: "http://www.example.com/"}}, got.Lines)
+		assert.Nil(t, got.Connection)
+		assert.Len(t, got.Media, 1)
+		assert.Equal(t, 2, got.Media[0].Ports)
+		assert.Equal(t, &Connection{NetworkType: "IN", AddressType: "IP6", Address: "2001:db8::2"}, got.Media[0].Connection)
+
+		_, found := got.Media[0].Attribute("recvonly")
+
+		assert.True(t, found)
+
+		_, found = got.Attribute("recvonly")
+
+		assert.False(t, found)
+	})
+
+	t.Run("encodes back the lines out of the model", func(t *testing.T) {
+		input := "v=0\r\n" +
+			"o=jdoe 2890844526 2890842807 IN IP4 10.47.16.5\r\n" +
+			"s=SDP Seminar\r\n" +
+			"i=A Seminar on the session description protocol\r\n" +
+			"u=http://www.example.com/seminars/sdp.pdf\r\n" +
+			"e=j.doe@example.com (Jane Doe)\r\n" +
+			"p=+1 617 555-6011\r\n" +
+			"c=IN IP4 224.2.17.12/127\r\n" +
+			"c=IN IP4 224.2.17.13/127\r\n" +
+			"b=CT:128\r\n" +
+			"t=2873397496 2873404696\r\n" +
+			"r=7d 1h 0 25h\r\n" +
+			"t=2873404696 2873411896\r\n" +
+			"z=2882844526 -1h 2898848070 0\r\n" +
+			"k=prompt\r\n" +
+			"a=recvonly\r\n" +
+			"m=audio 49170 RTP/AVP 0\r\n" +
+			"c=IN IP4 224.2.17.14/127\r\n" +
+			"c=IN IP4 224.2.17.15/127\r\n" +
+			"k=prompt\r\n" +
+			"a=rtpmap:0 PCMU/8000\r\n"
+
+		got := new(SessionDescription)
+
+		assert.Nil(t, SessionDescriptionCodec{}.Unmarshal("application/sdp", []byte(input), got))
+
+		assert.Equal(t, &Connection{NetworkType: "IN", AddressType: "IP4", Address: "224.2.17.12/127"}, got.Connection)
+		assert.Equal(t, Timing{Start: 2873397496, Stop: 2873404696}, got.Timing)
+		assert.Equal(t, []Line{{Type: 'c', Value: "IN IP4 224.2.17.15/127"}, {Type: 'k', Value: "prompt"}}, got.Media[0].Lines)
+
+		_, payload, err := SessionDescriptionCodec{}.Marshal("application/sdp", got)
+
+		assert.Nil(t, err)
+		assert.Equal(t, input, string(payload))
+	})
+
+	table := []struct {
+		name  string
+		input string
+	}{
+		{name: "missing version", input: "o=- 0 0 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\n"},
+		{name: "unsupported version", input: "v=1\r\no=- 0 0 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\n"},
+		{name: "missing origin", input: "v=0\r\ns=-\r\nt=0 0\r\n"},
+		{name: "invalid origin", input: "v=0\r\no=- 0 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\n"},
+		{name: "missing timing", input: "v=0\r\no=- 0 0 IN IP4 192.0.2.1\r\ns=-\r\n"},
+		{name: "malformed line", input: "v=0\r\no=- 0 0 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\nsendrecv\r\n"},
+		{name: "invalid port", input: "v=0\r\no=- 0 0 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\nm=audio 70000 RTP/AVP 0\r\n"},
+		{name: "missing formats", input: "v=0\r\no=- 0 0 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\nm=audio 49170 RTP/AVP\r\n"},
+		{name: "invalid bandwidth", input: "v=0\r\no=- 0 0 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\nb=AS\r\n"},
+		{name: "session line after a media", input: "v=0\r\no=- 0 0 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\nm=audio 0 RTP/AVP 0\r\ns=-\r\n"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			err := SessionDescriptionCodec{}.Unmarshal("application/sdp", []byte(tc.input), new(SessionDescription))
+
+			assert.ErrorIs(t, err, ErrInvalidBody)
+		})
+	}
+
+	_, _, err := SessionDescriptionCodec{}.Marshal("application/sdp", SessionDescription{Name: "-"})
+
+	assert.ErrorIs(t, err, ErrInvalidBody)
+}
@@ -0,0 +1,95 @@
+package body
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/message"
+)
+
+// DTMFRelay is an application/dtmf-relay body, sent in INFO requests to relay a single DTMF event, such as
+// "Signal=5\r\nDuration=160\r\n".
+type DTMFRelay struct {
+	// Signal is the key that was pressed: a digit, "*", "#", a letter from "A" to "D", or "!" for a hook flash.
+	Signal string
+
+	// Duration is the length of the event, in milliseconds.
+	Duration int
+}
+
+// DTMFRelayCodec is the message.BodyCodec of application/dtmf-relay bodies, which are decoded into and encoded from
+// a *DTMFRelay.
+type DTMFRelayCodec struct{}
+
+// Unmarshal parses a dtmf-relay body into v, which must be a *DTMFRelay.
+func (DTMFRelayCodec) Unmarshal(contentType string, payload []byte, v any) error {
+	relay, ok := v.(*DTMFRelay)
+
+	if !ok {
+		return fmt.Errorf("%w: cannot decode %s into %T", message.ErrUnsupportedBody, contentType, v)
+	}
+
+	*relay = DTMFRelay{}
+
+	for _, line := range bytes.Split(payload, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+
+		if len(line) == 0 {
+			continue
+		}
+
+		name, value, found := strings.Cut(string(line), "=")
+
+		if !found {
+			return fmt.Errorf("%w: %s: malformed line %q", ErrInvalidBody, contentType, line)
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "signal":
+			relay.Signal = value
+		case "duration":
+			duration, err := strconv.Atoi(value)
+
+			if err != nil || duration < 0 {
+				return fmt.Errorf("%w: %s: invalid duration %q", ErrInvalidBody, contentType, value)
+			}
+
+			relay.Duration = duration
+		}
+	}
+
+	if !isDTMFSignal(relay.Signal) {
+		return fmt.Errorf("%w: %s: invalid signal %q", ErrInvalidBody, contentType, relay.Signal)
+	}
+
+	return nil
+}
+
+// Marshal encodes v, which must be a DTMFRelay or a *DTMFRelay.
+func (DTMFRelayCodec) Marshal(contentType string, v any) (string, []byte, error) {
+	var relay DTMFRelay
+
+	switch value := v.(type) {
+	case DTMFRelay:
+		relay = value
+	case *DTMFRelay:
+		relay = *value
+	default:
+		return "", nil, fmt.Errorf("%w: cannot encode %T as %s", message.ErrUnsupportedBody, v, contentType)
+	}
+
+	if !isDTMFSignal(relay.Signal) || relay.Duration < 0 {
+		return "", nil, fmt.Errorf("%w: %s: invalid event %+v", ErrInvalidBody, contentType, relay)
+	}
+
+	return contentType, []byte(fmt.Sprintf("Signal=%s\r\nDuration=%d\r\n", relay.Signal, relay.Duration)), nil
+}
+
+// isDTMFSignal reports whether value is a DTMF key or a hook flash.
+func isDTMFSignal(value string) bool {
+	return len(value) == 1 && strings.Contains("0123456789*#ABCDabcd!", value)
+}
@@ -0,0 +1,12 @@
+package body
+
+import "fmt"
+
+// ErrInvalidBody when we have a body that does not match the syntax of its Content-Type.
+var ErrInvalidBody = fmt.Errorf("invalid SIP message body")
+
+// ErrInvalidMultipart when we have an invalid multipart body.
+var ErrInvalidMultipart = fmt.Errorf("invalid multipart body")
+
+// ErrOnGenerateBoundary occurs when we have an unexpected error when trying to generate a boundary.
+var ErrOnGenerateBoundary = fmt.Errorf("failed to generate a multipart boundary")
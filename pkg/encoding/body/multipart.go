@@ -0,0 +1,387 @@
+package body
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/message"
+)
+
+// maxBoundary is the maximum length of a boundary, as defined in RFC 2046 section 5.1.1.
+const maxBoundary = 70
+
+// Multipart is a multipart MIME body, such as the multipart/mixed bodies that carry SDP along with ISUP or PIDF-LO.
+type Multipart struct {
+	// Type is the media type of the body, such as "multipart/mixed" or "multipart/alternative".
+	Type string
+
+	// Boundary is the delimiter of the parts. Marshal generates a random one if it is empty.
+	Boundary string
+
+	// Params holds the parameters of the Content-Type other than the boundary, such as the type parameter of
+	// multipart/related.
+	Params map[string]string
+
+	// Parts holds the parts of the body, in order.
+	Parts []*Part
+}
+
+// Part is a single part of a multipart body.
+type Part struct {
+	// Headers holds the headers of the part, such as Content-Type, Content-Disposition and Content-ID.
+	Headers message.Headers
+
+	// Body holds the raw octets of the part. It is ignored when Multipart is set.
+	Body []byte
+
+	// Multipart holds the nested parts when the part is itself a multipart body. Its Content-Type takes the place
+	// of the one in Headers.
+	Multipart *Multipart
+}
+
+// NewMultipart returns a multipart body of the given subtype, such as "mixed", holding parts.
+func NewMultipart(subtype string, parts ...*Part) *Multipart {
+	return &Multipart{Type: "multipart/" + subtype, Params: make(map[string]string), Parts: parts}
+}
+
+// NewPart returns a part holding body with the given Content-Type.
+func NewPart(contentType string, body []byte) *Part {
+	return &Part{Headers: message.Headers{"Content-Type": {contentType}}, Body: body}
+}
+
+// NewNestedPart returns a part holding the nested multipart body.
+func NewNestedPart(body *Multipart) *Part {
+	return &Part{Headers: make(message.Headers), Multipart: body}
+}
+
+// NewBoundary returns a new random boundary.
+func NewBoundary() (string, error) {
+	octets := make([]byte, 16)
+
+	if _, err := rand.Read(octets); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrOnGenerateBoundary, err)
+	}
+
+	return hex.EncodeToString(octets), nil
+}
+
+// ContentType returns the value of the Content-Type header for the body, including its boundary.
+func (body *Multipart) ContentType() string {
+	params := make(map[string]string, len(body.Params)+1)
+
+	for name, value := range body.Params {
+		params[name] = value
+	}
+
+	params["boundary"] = body.Boundary
+
+	return mime.FormatMediaType(body.Type, params)
+}
+
+// Find returns the first part, searching nested parts depth first, whose media type is mediaType, or nil if there
+// is none.
+func (body *Multipart) Find(mediaType string) *Part {
+	for _, part := range body.Parts {
+		if part.Multipart != nil {
+			if found := part.Multipart.Find(mediaType); found != nil {
+				return found
+			}
+
+			continue
+		}
+
+		if found, _, err := part.ContentType(); err == nil && strings.EqualFold(found, mediaType) {
+			return part
+		}
+	}
+
+	return nil
+}
+
+// ContentType returns the media type and parameters of the part. A part without a Content-Type header is
+// text/plain, as defined in RFC 2046 section 5.1.
+func (part *Part) ContentType() (string, map[string]string, error) {
+	if part.Multipart != nil {
+		return part.Multipart.Type, part.Multipart.Params, nil
+	}
+
+	value := part.Headers.Get("Content-Type")
+
+	if value == "" {
+		return "text/plain", map[string]string{"charset": "us-ascii"}, nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(value)
+
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: Content-Type %q: %s", ErrInvalidMultipart, value, err)
+	}
+
+	return mediaType, params, nil
+}
+
+// Disposition returns the disposition type and parameters of the part. A part without a Content-Disposition header
+// has the "session" disposition if it holds SDP and "render" otherwise, as defined in RFC 3261 section 20.11.
+func (part *Part) Disposition() (string, map[string]string, error) {
+	value := part.Headers.Get("Content-Disposition")
+
+	if value == "" {
+		if mediaType, _, err := part.ContentType(); err == nil && mediaType == "application/sdp" {
+			return "session", make(map[string]string), nil
+		}
+
+		return "render", make(map[string]string), nil
+	}
+
+	disposition, params, err := mime.ParseMediaType(value)
+
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: Content-Disposition %q: %s", ErrInvalidMultipart, value, err)
+	}
+
+	return disposition, params, nil
+}
+
+// Unmarshal parses the multipart payload described by contentType, the value of its Content-Type header, and
+// stores the result in the Multipart pointed to by body. Parts that are themselves multipart are parsed as well.
+func Unmarshal(contentType string, payload []byte, body *Multipart) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+
+	if err != nil {
+		return fmt.Errorf("%w: Content-Type %q: %s", ErrInvalidMultipart, contentType, err)
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return fmt.Errorf("%w: %q is not a multipart media type", ErrInvalidMultipart, mediaType)
+	}
+
+	boundary := params["boundary"]
+
+	if !isBoundary(boundary) {
+		return fmt.Errorf("%w: invalid boundary %q", ErrInvalidMultipart, boundary)
+	}
+
+	delete(params, "boundary")
+
+	body.Type = mediaType
+	body.Boundary = boundary
+	body.Params = params
+	body.Parts = nil
+
+	reader := multipart.NewReader(bytes.NewReader(payload), boundary)
+
+	for {
+		// Raw parts keep their Content-Transfer-Encoding, which SIP bodies are expected to be sent without
+		raw, err := reader.NextRawPart()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("%w: part %d: %s", ErrInvalidMultipart, len(body.Parts)+1, err)
+		}
+
+		part := &Part{Headers: make(message.Headers)}
+
+		for name, values := range raw.Header {
+			for _, value := range values {
+				part.Headers.Add(name, value)
+			}
+		}
+
+		if part.Body, err = io.ReadAll(raw); err != nil {
+			return fmt.Errorf("%w: part %d: %s", ErrInvalidMultipart, len(body.Parts)+1, err)
+		}
+
+		if value := part.Headers.Get("Content-Type"); strings.HasPrefix(strings.ToLower(value), "multipart/") {
+			part.Multipart = new(Multipart)
+
+			if err := Unmarshal(value, part.Body, part.Multipart); err != nil {
+				return err
+			}
+
+			part.Body = nil
+			part.Headers.Del("Content-Type")
+		}
+
+		body.Parts = append(body.Parts, part)
+	}
+
+	if len(body.Parts) == 0 {
+		return fmt.Errorf("%w: no parts", ErrInvalidMultipart)
+	}
+
+	return nil
+}
+
+// Marshal returns the multipart encoding of body, generating its boundary, and the ones of its nested parts, if
+// they are empty.
+func Marshal(body *Multipart) ([]byte, error) {
+	if body == nil || !strings.HasPrefix(strings.ToLower(body.Type), "multipart/") {
+		return nil, fmt.Errorf("%w: missing multipart media type", ErrInvalidMultipart)
+	}
+
+	if len(body.Parts) == 0 {
+		return nil, fmt.Errorf("%w: no parts", ErrInvalidMultipart)
+	}
+
+	encoded := make([][]byte, len(body.Parts))
+
+	for index, part := range body.Parts {
+		payload, err := marshalPart(part)
+
+		if err != nil {
+			return nil, err
+		}
+
+		encoded[index] = payload
+	}
+
+	if body.Boundary == "" {
+		boundary, err := NewBoundary()
+
+		if err != nil {
+			return nil, err
+		}
+
+		body.Boundary = boundary
+	}
+
+	if !isBoundary(body.Boundary) {
+		return nil, fmt.Errorf("%w: invalid boundary %q", ErrInvalidMultipart, body.Boundary)
+	}
+
+	delimiter := []byte("--" + body.Boundary)
+
+	for index, payload := range encoded {
+		if bytes.Contains(payload, delimiter) {
+			return nil, fmt.Errorf("%w: boundary %q appears in part %d", ErrInvalidMultipart, body.Boundary, index+1)
+		}
+	}
+
+	var buffer bytes.Buffer
+
+	for _, payload := range encoded {
+		buffer.Write(delimiter)
+		buffer.Write(message.CRLF)
+		buffer.Write(payload)
+		buffer.Write(message.CRLF)
+	}
+
+	buffer.Write(delimiter)
+	buffer.WriteString("--")
+	buffer.Write(message.CRLF)
+
+	return buffer.Bytes(), nil
+}
+
+// marshalPart returns the headers and content of part. Content-Type and Content-Disposition come first, followed by
+// the other headers in alphabetical order.
+func marshalPart(part *Part) ([]byte, error) {
+	if part == nil {
+		return nil, fmt.Errorf("%w: nil part", ErrInvalidMultipart)
+	}
+
+	content := part.Body
+	contentType := part.Headers.Values("Content-Type")
+
+	if part.Multipart != nil {
+		nested, err := Marshal(part.Multipart)
+
+		if err != nil {
+			return nil, err
+		}
+
+		content = nested
+		contentType = []string{part.Multipart.ContentType()}
+	}
+
+	var buffer bytes.Buffer
+
+	writeHeader := func(name string, values []string) {
+		for _, value := range values {
+			buffer.WriteString(name)
+			buffer.WriteString(": ")
+			buffer.WriteString(value)
+			buffer.Write(message.CRLF)
+		}
+	}
+
+	writeHeader("Content-Type", contentType)
+	writeHeader("Content-Disposition", part.Headers.Values("Content-Disposition"))
+
+	names := make([]string, 0, len(part.Headers))
+
+	for name := range part.Headers {
+		if name != "Content-Type" && name != "Content-Disposition" {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		writeHeader(name, part.Headers[name])
+	}
+
+	buffer.Write(message.CRLF)
+	buffer.Write(content)
+
+	return buffer.Bytes(), nil
+}
+
+// ReadMessage parses the multipart body of msg, described by its Content-Type header.
+func ReadMessage(msg *message.Message) (*Multipart, error) {
+	body := new(Multipart)
+
+	if err := Unmarshal(msg.Headers.Get("Content-Type"), msg.Body, body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// WriteMessage sets the body of msg to the encoding of body, and its Content-Type and Content-Length headers to
+// match it.
+func WriteMessage(msg *message.Message, body *Multipart) error {
+	payload, err := Marshal(body)
+
+	if err != nil {
+		return err
+	}
+
+	msg.Body = payload
+	msg.SetHeader("Content-Type", body.ContentType())
+	msg.SetHeader("Content-Length", strconv.Itoa(len(payload)))
+
+	return nil
+}
+
+// isBoundary reports whether value is a valid boundary, as defined in RFC 2046 section 5.1.1.
+func isBoundary(value string) bool {
+	if value == "" || len(value) > maxBoundary || strings.HasSuffix(value, " ") {
+		return false
+	}
+
+	for index := 0; index < len(value); index++ {
+		char := value[index]
+
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		case strings.IndexByte("'()+_,-./:=? ", char) >= 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
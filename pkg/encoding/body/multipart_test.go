@@ -0,0 +1,358 @@
+package body
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/otoru/party/pkg/encoding/message"
+	"github.com/stretchr/testify/assert"
+)
+
+var sdp = "v=0\r\n" +
+	"o=alice 2890844526 2890842807 IN IP4 192.0.2.101\r\n" +
+	"s=-\r\n" +
+	"c=IN IP4 192.0.2.101\r\n" +
+	"t=0 0\r\n" +
+	"m=audio 49170 RTP/AVP 0\r\n"
+
+func TestUnmarshalWithValidBodies(t *testing.T) {
+	table := []struct {
+		contentType string
+		input       []byte
+		want        *Multipart
+	}{
+		{
+			// SDP along with ISUP, as sent by SIP-I gateways
+			contentType: "multipart/mixed; boundary=unique-boundary-1",
+			input: []byte("--unique-boundary-1\r\n" +
+				"Content-Type: application/sdp\r\n" +
+				"\r\n" +
+				sdp +
+				"\r\n" +
+				"--unique-boundary-1\r\n" +
+				"Content-Type: application/isup; version=itu-t92+\r\n" +
+				"Content-Disposition: signal; handling=optional\r\n" +
+				"\r\n" +
+				"\x01\x00\x49\x00\x00\x03\x02\x00\x07\x04\x10\x00\x33\x63\x21\x43\x00\x00\x03\r\n" +
+				"--unique-boundary-1--\r\n"),
+			want: &Multipart{
+				Type:     "multipart/mixed",
+				Boundary: "unique-boundary-1",
+				Params:   map[string]string{},
+				Parts: []*Part{
+					{
+						Headers: message.Headers{"Content-Type": {"application/sdp"}},
+						Body:    []byte(sdp),
+					},
+					{
+						Headers: message.Headers{
+							"Content-Type":        {"application/isup; version=itu-t92+"},
+							"Content-Disposition": {"signal; handling=optional"},
+						},
+						Body: []byte("\x01\x00\x49\x00\x00\x03\x02\x00\x07\x04\x10\x00\x33\x63\x21\x43\x00\x00\x03"),
+					},
+				},
+			},
+		},
+		{
+			// A preamble, an epilogue and a part without headers, which is text/plain
+			contentType: "Multipart/Related; type=\"application/sdp\"; boundary=\"boundary 42\"",
+			input: []byte("This is the preamble\r\n" +
+				"--boundary 42\r\n" +
+				"\r\n" +
+				"Hello\r\n" +
+				"--boundary 42--\r\n" +
+				"This is the epilogue\r\n"),
+			want: &Multipart{
+				Type:     "multipart/related",
+				Boundary: "boundary 42",
+				Params:   map[string]string{"type": "application/sdp"},
+				Parts: []*Part{
+					{Headers: message.Headers{}, Body: []byte("Hello")},
+				},
+			},
+		},
+		{
+			// Nested parts, where the SDP has an alternative and the location goes along with them
+			contentType: "multipart/mixed;boundary=outer",
+			input: []byte("--outer\r\n" +
+				"Content-Type: multipart/alternative; boundary=inner\r\n" +
+				"\r\n" +
+				"--inner\r\n" +
+				"Content-Type: application/sdp\r\n" +
+				"\r\n" +
+				sdp +
+				"\r\n" +
+				"--inner\r\n" +
+				"Content-Type: text/plain\r\n" +
+				"\r\n" +
+				"audio\r\n" +
+				"--inner--\r\n" +
+				"\r\n" +
+				"--outer\r\n" +
+				"Content-Type: application/pidf+xml\r\n" +
+				"Content-ID: <target123@atlanta.example.com>\r\n" +
+				"\r\n" +
+				"<presence/>\r\n" +
+				"--outer--\r\n"),
+			want: &Multipart{
+				Type:     "multipart/mixed",
+				Boundary: "outer",
+				Params:   map[string]string{},
+				Parts: []*Part{
+					{
+						Headers: message.Headers{},
+						Multipart: &Multipart{
+							Type:     "multipart/alternative",
+							Boundary: "inner",
+							Params:   map[string]string{},
+							Parts: []*Part{
+								{Headers: message.Headers{"Content-Type": {"application/sdp"}}, Body: []byte(sdp)},
+								{Headers: message.Headers{"Content-Type": {"text/plain"}}, Body: []byte("audio")},
+							},
+						},
+					},
+					{
+						Headers: message.Headers{
+							"Content-Type": {"application/pidf+xml"},
+							"Content-ID":   {"<target123@atlanta.example.com>"},
+						},
+						Body: []byte("<presence/>"),
+					},
+				},
+			},
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			got := new(Multipart)
+			err := Unmarshal(test.contentType, test.input, got)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, got, test.want)
+		})
+	}
+}
+
+func TestUnmarshalWithInvalidBodies(t *testing.T) {
+	table := []struct {
+		contentType string
+		input       []byte
+	}{
+		{contentType: "", input: []byte("--b\r\n\r\nHello\r\n--b--\r\n")},
+		{contentType: "application/sdp", input: []byte(sdp)},
+		{contentType: "multipart/mixed", input: []byte("--b\r\n\r\nHello\r\n--b--\r\n")},
+		{contentType: "multipart/mixed; boundary=\"ends with space \"", input: []byte("")},
+		{contentType: "multipart/mixed; boundary=b", input: []byte("--b\r\n\r\nHello\r\n")},
+		{contentType: "multipart/mixed; boundary=b", input: []byte("--b--\r\n")},
+		{contentType: "multipart/mixed; boundary=b", input: []byte("no delimiter at all")},
+		{
+			contentType: "multipart/mixed; boundary=b",
+			input:       []byte("--b\r\nContent-Type: multipart/alternative\r\n\r\nHello\r\n--b--\r\n"),
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			err := Unmarshal(test.contentType, test.input, new(Multipart))
+
+			assert.ErrorIs(t, err, ErrInvalidMultipart)
+		})
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	location := NewPart("application/pidf+xml", []byte("<presence/>"))
+	location.Headers.Set("Content-ID", "<target123@atlanta.example.com>")
+
+	isup := NewPart("application/isup; version=itu-t92+", []byte("\x01\x00\x49\x00"))
+	isup.Headers.Set("Content-Disposition", "signal; handling=optional")
+
+	alternative := NewMultipart("alternative", NewPart("application/sdp", []byte(sdp)), isup)
+	body := NewMultipart("mixed", NewNestedPart(alternative), location)
+
+	payload, err := Marshal(body)
+
+	assert.Nil(t, err)
+
+	assert.NotEmpty(t, body.Boundary)
+
+	assert.NotEmpty(t, alternative.Boundary)
+
+	assert.NotEqual(t, body.Boundary, alternative.Boundary)
+
+	got := new(Multipart)
+
+	assert.Nil(t, Unmarshal(body.ContentType(), payload, got))
+
+	assert.Equal(t, got, body)
+
+	again, err := Marshal(got)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, again, payload)
+}
+
+func TestMarshalWithInvalidBodies(t *testing.T) {
+	table := []struct {
+		name  string
+		input *Multipart
+	}{
+		{name: "nil body", input: nil},
+		{name: "not multipart", input: &Multipart{Type: "application/sdp", Parts: []*Part{NewPart("text/plain", nil)}}},
+		{name: "no parts", input: NewMultipart("mixed")},
+		{name: "nil part", input: NewMultipart("mixed", nil)},
+		{
+			name:  "invalid boundary",
+			input: &Multipart{Type: "multipart/mixed", Boundary: "semi;colon", Parts: []*Part{NewPart("text/plain", nil)}},
+		},
+		{
+			name:  "boundary in a part",
+			input: &Multipart{Type: "multipart/mixed", Boundary: "b", Parts: []*Part{NewPart("text/plain", []byte("\r\n--b--"))}},
+		},
+		{
+			name: "same boundary in a nested part",
+			input: &Multipart{
+				Type:     "multipart/mixed",
+				Boundary: "b",
+				Parts: []*Part{
+					NewNestedPart(&Multipart{Type: "multipart/alternative", Boundary: "b", Parts: []*Part{NewPart("text/plain", nil)}}),
+				},
+			},
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Marshal(tc.input)
+
+			assert.ErrorIs(t, err, ErrInvalidMultipart)
+		})
+	}
+}
+
+func TestPartHeaders(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		part := &Part{Headers: message.Headers{}, Body: []byte("Hello")}
+
+		mediaType, params, err := part.ContentType()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "text/plain", mediaType)
+		assert.Equal(t, map[string]string{"charset": "us-ascii"}, params)
+
+		disposition, _, err := part.Disposition()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "render", disposition)
+
+		disposition, _, err = NewPart("application/sdp", []byte(sdp)).Disposition()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "session", disposition)
+	})
+
+	t.Run("explicit values", func(t *testing.T) {
+		part := NewPart("application/ISUP; version=itu-t92+", nil)
+		part.Headers.Set("Content-Disposition", "signal; handling=optional")
+
+		mediaType, params, err := part.ContentType()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "application/isup", mediaType)
+		assert.Equal(t, map[string]string{"version": "itu-t92+"}, params)
+
+		disposition, params, err := part.Disposition()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "signal", disposition)
+		assert.Equal(t, map[string]string{"handling": "optional"}, params)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		part := NewPart("application/", nil)
+		part.Headers.Set("Content-Disposition", ";handling=optional")
+
+		_, _, err := part.ContentType()
+
+		assert.ErrorIs(t, err, ErrInvalidMultipart)
+
+		_, _, err = part.Disposition()
+
+		assert.ErrorIs(t, err, ErrInvalidMultipart)
+	})
+}
+
+func TestMultipartFind(t *testing.T) {
+	location := NewPart("application/pidf+xml", []byte("<presence/>"))
+	offer := NewPart("application/sdp", []byte(sdp))
+	body := NewMultipart("mixed", NewNestedPart(NewMultipart("alternative", NewPart("text/plain", nil), offer)), location)
+
+	assert.Equal(t, offer, body.Find("application/sdp"))
+
+	assert.Equal(t, location, body.Find("Application/PIDF+XML"))
+
+	assert.Nil(t, body.Find("application/isup"))
+}
+
+func TestMessageBody(t *testing.T) {
+	request, err := message.CreateSIPRequest(
+		message.Metadata{"method": "INVITE", "uri": "sip:bob@biloxi.com", "version": message.SIPVersion},
+		message.Headers{
+			"Via":          {"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds"},
+			"Max-Forwards": {"70"},
+			"To":           {"Bob <sip:bob@biloxi.com>"},
+			"From":         {"Alice <sip:alice@atlanta.com>;tag=1928301774"},
+			"Call-ID":      {"a84b4c76e66710"},
+		},
+		nil,
+	)
+
+	assert.Nil(t, err)
+
+	body := NewMultipart("mixed", NewPart("application/sdp", []byte(sdp)), NewPart("application/pidf+xml", []byte("<presence/>")))
+
+	assert.Nil(t, WriteMessage(request, body))
+
+	length, err := request.Headers.ContentLength()
+
+	assert.Nil(t, err)
+	assert.Equal(t, len(request.Body), length)
+	assert.Equal(t, body.ContentType(), request.Headers.Get("Content-Type"))
+
+	payload, err := message.Marshal(request)
+
+	assert.Nil(t, err)
+
+	received := new(message.Message)
+
+	assert.Nil(t, message.Unmarshal(payload, received))
+
+	got, err := ReadMessage(received)
+
+	assert.Nil(t, err)
+	assert.Equal(t, body, got)
+}
+
+func TestReadMessageWithTortureMessage(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("..", "message", "testdata", "rfc4475", "valid", "mpart01.dat"))
+
+	assert.Nil(t, err)
+
+	received := new(message.Message)
+
+	assert.Nil(t, message.Unmarshal(payload, received))
+
+	got, err := ReadMessage(received)
+
+	assert.Nil(t, err)
+	assert.Len(t, got.Parts, 2)
+	assert.Equal(t, []byte("Hello"), got.Parts[0].Body)
+	assert.Equal(t, "application/octet-stream", got.Parts[1].Headers.Get("Content-Type"))
+	assert.Equal(t, []string{"binary"}, got.Parts[1].Headers.Values("Content-Transfer-Encoding"))
+}
@@ -0,0 +1,505 @@
+package body
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/message"
+)
+
+// SessionDescription is an application/sdp body, as defined in RFC 4566, sent in the offer/answer exchanges of RFC
+// 3264 to negotiate the media of a session.
+//
+// It holds the session-level lines and the media descriptions that follow them. The lines that are not part of the
+// model, such as the u, e, p, r, z and k lines, a second c line or the t lines after the first, are kept in Lines so
+// that a decoded description is encoded back unchanged.
+type SessionDescription struct {
+	// Version is the protocol version of the v line, which is always 0.
+	Version int
+
+	// Origin is the originator of the session and its identifier, from the o line.
+	Origin Origin
+
+	// Name is the session name of the s line, "-" when the session has none.
+	Name string
+
+	// Information is the session information of the optional i line.
+	Information string
+
+	// Connection is the connection data of the optional session-level c line, used by the media descriptions that
+	// have none.
+	Connection *Connection
+
+	// Bandwidths holds the session-level b lines.
+	Bandwidths []Bandwidth
+
+	// Timing is the start and stop time of the t line, both 0 for a permanent session.
+	Timing Timing
+
+	// Attributes holds the session-level a lines, in order.
+	Attributes []Attribute
+
+	// Media holds the media descriptions, one per m line, in order.
+	Media []MediaDescription
+
+	// Lines holds the session-level lines that are not part of the model, in order. Marshal writes the u, e and p
+	// lines before the c line, the c lines after it, and the others after the t line.
+	Lines []Line
+}
+
+// Line is a raw line of a session description, such as the "u=http://www.example.com/seminars/sdp.pdf" line.
+type Line struct {
+	Type  byte
+	Value string
+}
+
+// Origin is the o line of a session description, such as "alice 2890844526 2890842807 IN IP4 192.0.2.101".
+type Origin struct {
+	Username       string
+	SessionID      string
+	SessionVersion string
+	NetworkType    string
+	AddressType    string
+	Address        string
+}
+
+// Connection is the c line of a session or media description, such as "IN IP4 192.0.2.101".
+type Connection struct {
+	NetworkType string
+	AddressType string
+	Address     string
+}
+
+// Bandwidth is a b line, such as "AS:64", where the value is in kilobits per second.
+type Bandwidth struct {
+	Type  string
+	Value int
+}
+
+// Timing is the t line of a session description, with times in NTP seconds.
+type Timing struct {
+	Start uint64
+	Stop  uint64
+}
+
+// Attribute is an a line, either a property such as "sendrecv", with an empty value, or a value attribute such as
+// "rtpmap:0 PCMU/8000".
+type Attribute struct {
+	Name  string
+	Value string
+}
+
+// MediaDescription is a media section of a session description, which starts with an m line such as
+// "audio 49170 RTP/AVP 0 8".
+type MediaDescription struct {
+	// Type is the media type, such as "audio", "video" or "application".
+	Type string
+
+	// Port is the transport port, 0 for a rejected or disabled stream.
+	Port int
+
+	// Ports is the number of ports of the optional "/<number of ports>" suffix, 0 when it is absent.
+	Ports int
+
+	// Protocol is the transport protocol, such as "RTP/AVP".
+	Protocol string
+
+	// Formats holds the media formats, such as the RTP payload types "0" and "8".
+	Formats []string
+
+	// Information is the media title of the optional i line.
+	Information string
+
+	// Connection is the connection data of the optional c line, which overrides the session-level one.
+	Connection *Connection
+
+	// Bandwidths holds the b lines of the media.
+	Bandwidths []Bandwidth
+
+	// Attributes holds the a lines of the media, in order.
+	Attributes []Attribute
+
+	// Lines holds the lines of the media that are not part of the model, such as the k line or the c lines after the
+	// first, in order. Marshal writes the c lines after the c line, and the others before the a lines.
+	Lines []Line
+}
+
+// Attribute returns the value of the first session-level attribute name, and whether it is present.
+func (session *SessionDescription) Attribute(name string) (string, bool) {
+	return findAttribute(session.Attributes, name)
+}
+
+// Attribute returns the value of the first attribute name of the media, and whether it is present.
+func (media *MediaDescription) Attribute(name string) (string, bool) {
+	return findAttribute(media.Attributes, name)
+}
+
+// SessionDescriptionCodec is the message.BodyCodec of application/sdp bodies, which are decoded into and encoded from
+// a *SessionDescription.
+type SessionDescriptionCodec struct{}
+
+// Unmarshal parses a session description into v, which must be a *SessionDescription. Lines may end with CRLF or a
+// bare LF.
+func (SessionDescriptionCodec) Unmarshal(contentType string, payload []byte, v any) error {
+	session, ok := v.(*SessionDescription)
+
+	if !ok {
+		return fmt.Errorf("%w: cannot decode %s into %T", message.ErrUnsupportedBody, contentType, v)
+	}
+
+	*session = SessionDescription{}
+
+	// seen holds the session-level lines found, to check the required ones
+	seen := make(map[byte]bool)
+
+	var media *MediaDescription
+
+	for index, line := range bytes.Split(payload, []byte("\n")) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+
+		if len(line) == 0 {
+			continue
+		}
+
+		if len(line) < 2 || line[1] != '=' {
+			return fmt.Errorf("%w: %s: malformed line %q", ErrInvalidBody, contentType, line)
+		}
+
+		kind, value := line[0], string(line[2:])
+
+		if index == 0 && kind != 'v' {
+			return fmt.Errorf("%w: %s: missing version line", ErrInvalidBody, contentType)
+		}
+
+		var err error
+
+		switch {
+		case kind == 'm':
+			session.Media = append(session.Media, MediaDescription{})
+			media = &session.Media[len(session.Media)-1]
+			err = unmarshalMediaLine(value, media)
+		case media != nil:
+			err = unmarshalMediaField(kind, value, media)
+		case seen[kind] && (kind == 'c' || kind == 't'):
+			session.Lines = append(session.Lines, Line{Type: kind, Value: value})
+		default:
+			seen[kind] = true
+			err = unmarshalSessionField(kind, value, session)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidBody, contentType, err)
+		}
+	}
+
+	for _, kind := range []byte("vost") {
+		if !seen[kind] {
+			return fmt.Errorf("%w: %s: missing %c line", ErrInvalidBody, contentType, kind)
+		}
+	}
+
+	return nil
+}
+
+// Marshal encodes v, which must be a SessionDescription or a *SessionDescription, with its lines in the order
+// required by RFC 4566 section 5.
+func (SessionDescriptionCodec) Marshal(contentType string, v any) (string, []byte, error) {
+	var session SessionDescription
+
+	switch value := v.(type) {
+	case SessionDescription:
+		session = value
+	case *SessionDescription:
+		session = *value
+	default:
+		return "", nil, fmt.Errorf("%w: cannot encode %T as %s", message.ErrUnsupportedBody, v, contentType)
+	}
+
+	origin := session.Origin
+
+	if session.Name == "" {
+		return "", nil, fmt.Errorf("%w: %s: missing session name", ErrInvalidBody, contentType)
+	}
+
+	for _, field := range []string{origin.Username, origin.SessionID, origin.SessionVersion, origin.NetworkType, origin.AddressType, origin.Address} {
+		if field == "" || strings.Contains(field, " ") {
+			return "", nil, fmt.Errorf("%w: %s: invalid origin %+v", ErrInvalidBody, contentType, origin)
+		}
+	}
+
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "v=%d\r\n", session.Version)
+	fmt.Fprintf(&buffer, "o=%s %s %s %s %s %s\r\n", origin.Username, origin.SessionID, origin.SessionVersion, origin.NetworkType, origin.AddressType, origin.Address)
+	fmt.Fprintf(&buffer, "s=%s\r\n", session.Name)
+
+	if session.Information != "" {
+		fmt.Fprintf(&buffer, "i=%s\r\n", session.Information)
+	}
+
+	marshalLines(&buffer, session.Lines, "uep", true)
+	marshalConnection(&buffer, session.Connection)
+	marshalLines(&buffer, session.Lines, "c", true)
+	marshalBandwidths(&buffer, session.Bandwidths)
+
+	fmt.Fprintf(&buffer, "t=%d %d\r\n", session.Timing.Start, session.Timing.Stop)
+
+	marshalLines(&buffer, session.Lines, "uepc", false)
+	marshalAttributes(&buffer, session.Attributes)
+
+	for _, media := range session.Media {
+		if media.Type == "" || media.Protocol == "" || len(media.Formats) == 0 || media.Port < 0 || media.Ports < 0 {
+			return "", nil, fmt.Errorf("%w: %s: invalid media description %+v", ErrInvalidBody, contentType, media)
+		}
+
+		port := strconv.Itoa(media.Port)
+
+		if media.Ports > 0 {
+			port += "/" + strconv.Itoa(media.Ports)
+		}
+
+		fmt.Fprintf(&buffer, "m=%s %s %s %s\r\n", media.Type, port, media.Protocol, strings.Join(media.Formats, " "))
+
+		if media.Information != "" {
+			fmt.Fprintf(&buffer, "i=%s\r\n", media.Information)
+		}
+
+		marshalConnection(&buffer, media.Connection)
+		marshalLines(&buffer, media.Lines, "c", true)
+		marshalBandwidths(&buffer, media.Bandwidths)
+		marshalLines(&buffer, media.Lines, "c", false)
+		marshalAttributes(&buffer, media.Attributes)
+	}
+
+	return contentType, buffer.Bytes(), nil
+}
+
+// unmarshalSessionField parses the value of a session-level line of type kind into session.
+func unmarshalSessionField(kind byte, value string, session *SessionDescription) error {
+	switch kind {
+	case 'v':
+		if value != "0" {
+			return fmt.Errorf("unsupported version %q", value)
+		}
+	case 'o':
+		fields := strings.Split(value, " ")
+
+		if len(fields) != 6 || !isDigits(fields[1]) || !isDigits(fields[2]) {
+			return fmt.Errorf("invalid origin %q", value)
+		}
+
+		session.Origin = Origin{
+			Username:       fields[0],
+			SessionID:      fields[1],
+			SessionVersion: fields[2],
+			NetworkType:    fields[3],
+			AddressType:    fields[4],
+			Address:        fields[5],
+		}
+	case 's':
+		if value == "" {
+			return fmt.Errorf("empty session name")
+		}
+
+		session.Name = value
+	case 't':
+		start, stop, found := strings.Cut(value, " ")
+		first, err := strconv.ParseUint(start, 10, 64)
+
+		if err != nil || !found {
+			return fmt.Errorf("invalid timing %q", value)
+		}
+
+		last, err := strconv.ParseUint(stop, 10, 64)
+
+		if err != nil {
+			return fmt.Errorf("invalid timing %q", value)
+		}
+
+		session.Timing = Timing{Start: first, Stop: last}
+	case 'i':
+		session.Information = value
+	case 'c':
+		connection, err := unmarshalConnection(value)
+
+		if err != nil {
+			return err
+		}
+
+		session.Connection = connection
+	case 'b':
+		bandwidth, err := unmarshalBandwidth(value)
+
+		if err != nil {
+			return err
+		}
+
+		session.Bandwidths = append(session.Bandwidths, bandwidth)
+	case 'a':
+		session.Attributes = append(session.Attributes, unmarshalAttribute(value))
+	default:
+		session.Lines = append(session.Lines, Line{Type: kind, Value: value})
+	}
+
+	return nil
+}
+
+// unmarshalMediaLine parses the value of an m line into media.
+func unmarshalMediaLine(value string, media *MediaDescription) error {
+	fields := strings.Split(value, " ")
+
+	if len(fields) < 4 || fields[0] == "" || fields[2] == "" {
+		return fmt.Errorf("invalid media description %q", value)
+	}
+
+	port, count, found := strings.Cut(fields[1], "/")
+	number, err := strconv.Atoi(port)
+
+	if err != nil || number < 0 || number > 65535 {
+		return fmt.Errorf("invalid port %q", fields[1])
+	}
+
+	media.Type = fields[0]
+	media.Port = number
+	media.Protocol = fields[2]
+	media.Formats = fields[3:]
+
+	if found {
+		if media.Ports, err = strconv.Atoi(count); err != nil || media.Ports < 1 {
+			return fmt.Errorf("invalid port %q", fields[1])
+		}
+	}
+
+	return nil
+}
+
+// unmarshalMediaField parses the value of a line of type kind that follows an m line into media.
+func unmarshalMediaField(kind byte, value string, media *MediaDescription) error {
+	switch kind {
+	case 'i':
+		media.Information = value
+	case 'c':
+		if media.Connection != nil {
+			media.Lines = append(media.Lines, Line{Type: kind, Value: value})
+
+			return nil
+		}
+
+		connection, err := unmarshalConnection(value)
+
+		if err != nil {
+			return err
+		}
+
+		media.Connection = connection
+	case 'b':
+		bandwidth, err := unmarshalBandwidth(value)
+
+		if err != nil {
+			return err
+		}
+
+		media.Bandwidths = append(media.Bandwidths, bandwidth)
+	case 'a':
+		media.Attributes = append(media.Attributes, unmarshalAttribute(value))
+	case 'v', 'o', 's', 't':
+		return fmt.Errorf("%c line after a media description", kind)
+	default:
+		media.Lines = append(media.Lines, Line{Type: kind, Value: value})
+	}
+
+	return nil
+}
+
+// unmarshalConnection parses the value of a c line.
+func unmarshalConnection(value string) (*Connection, error) {
+	fields := strings.Split(value, " ")
+
+	if len(fields) != 3 || fields[0] == "" || fields[1] == "" || fields[2] == "" {
+		return nil, fmt.Errorf("invalid connection %q", value)
+	}
+
+	return &Connection{NetworkType: fields[0], AddressType: fields[1], Address: fields[2]}, nil
+}
+
+// unmarshalBandwidth parses the value of a b line.
+func unmarshalBandwidth(value string) (Bandwidth, error) {
+	kind, amount, found := strings.Cut(value, ":")
+	number, err := strconv.Atoi(amount)
+
+	if !found || kind == "" || err != nil || number < 0 {
+		return Bandwidth{}, fmt.Errorf("invalid bandwidth %q", value)
+	}
+
+	return Bandwidth{Type: kind, Value: number}, nil
+}
+
+// unmarshalAttribute parses the value of an a line.
+func unmarshalAttribute(value string) Attribute {
+	name, attribute, _ := strings.Cut(value, ":")
+
+	return Attribute{Name: name, Value: attribute}
+}
+
+// marshalConnection writes the c line of connection, if any.
+func marshalConnection(buffer *bytes.Buffer, connection *Connection) {
+	if connection != nil {
+		fmt.Fprintf(buffer, "c=%s %s %s\r\n", connection.NetworkType, connection.AddressType, connection.Address)
+	}
+}
+
+// marshalBandwidths writes a b line for each of bandwidths.
+func marshalBandwidths(buffer *bytes.Buffer, bandwidths []Bandwidth) {
+	for _, bandwidth := range bandwidths {
+		fmt.Fprintf(buffer, "b=%s:%d\r\n", bandwidth.Type, bandwidth.Value)
+	}
+}
+
+// marshalAttributes writes an a line for each of attributes.
+func marshalAttributes(buffer *bytes.Buffer, attributes []Attribute) {
+	for _, attribute := range attributes {
+		if attribute.Value == "" {
+			fmt.Fprintf(buffer, "a=%s\r\n", attribute.Name)
+		} else {
+			fmt.Fprintf(buffer, "a=%s:%s\r\n", attribute.Name, attribute.Value)
+		}
+	}
+}
+
+// marshalLines writes, in order, the lines whose type is in types when included is true, or is not in types when it
+// is false.
+func marshalLines(buffer *bytes.Buffer, lines []Line, types string, included bool) {
+	for _, line := range lines {
+		if (strings.IndexByte(types, line.Type) >= 0) == included {
+			fmt.Fprintf(buffer, "%c=%s\r\n", line.Type, line.Value)
+		}
+	}
+}
+
+// findAttribute returns the value of the first attribute name of attributes, and whether it is present.
+func findAttribute(attributes []Attribute, name string) (string, bool) {
+	for _, attribute := range attributes {
+		if attribute.Name == name {
+			return attribute.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// isDigits reports whether value is a non-empty string of decimal digits.
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for index := 0; index < len(value); index++ {
+		if char := value[index]; char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
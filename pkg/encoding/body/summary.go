@@ -0,0 +1,210 @@
+package body
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/message"
+)
+
+// MessageCount holds the number of messages of a message context class, such as Voice-Message.
+type MessageCount struct {
+	New       int
+	Old       int
+	NewUrgent int
+	OldUrgent int
+}
+
+// MessageSummary is an application/simple-message-summary body, sent in the NOTIFY requests of the message-summary
+// event package defined in RFC 3842 to report waiting messages.
+type MessageSummary struct {
+	// Waiting reports whether there are messages waiting.
+	Waiting bool
+
+	// Account is the URI of the message account, if any.
+	Account string
+
+	// Messages holds the number of messages of each message context class, keyed by its canonical name, such as
+	// "Voice-Message".
+	Messages map[string]MessageCount
+}
+
+// MessageSummaryCodec is the message.BodyCodec of application/simple-message-summary bodies, which are decoded into
+// and encoded from a *MessageSummary.
+type MessageSummaryCodec struct{}
+
+// Unmarshal parses a simple-message-summary body into v, which must be a *MessageSummary. The optional message
+// headers that follow the blank line are ignored.
+func (MessageSummaryCodec) Unmarshal(contentType string, payload []byte, v any) error {
+	summary, ok := v.(*MessageSummary)
+
+	if !ok {
+		return fmt.Errorf("%w: cannot decode %s into %T", message.ErrUnsupportedBody, contentType, v)
+	}
+
+	*summary = MessageSummary{Messages: make(map[string]MessageCount)}
+
+	var waiting bool
+
+	for _, line := range bytes.Split(payload, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+
+		if len(line) == 0 {
+			break
+		}
+
+		name, value, found := strings.Cut(string(line), ":")
+
+		if !found {
+			return fmt.Errorf("%w: %s: malformed line %q", ErrInvalidBody, contentType, line)
+		}
+
+		name = canonicalSummaryName(name)
+		value = strings.TrimSpace(value)
+
+		switch name {
+		case "Messages-Waiting":
+			switch strings.ToLower(value) {
+			case "yes":
+				summary.Waiting = true
+			case "no":
+			default:
+				return fmt.Errorf("%w: %s: invalid Messages-Waiting %q", ErrInvalidBody, contentType, value)
+			}
+
+			waiting = true
+		case "Message-Account":
+			summary.Account = value
+		default:
+			count, err := parseMessageCount(value)
+
+			if err != nil {
+				return fmt.Errorf("%w: %s: invalid %s %q", ErrInvalidBody, contentType, name, value)
+			}
+
+			summary.Messages[name] = count
+		}
+	}
+
+	if !waiting {
+		return fmt.Errorf("%w: %s: missing Messages-Waiting", ErrInvalidBody, contentType)
+	}
+
+	return nil
+}
+
+// Marshal encodes v, which must be a MessageSummary or a *MessageSummary. Message context classes are written in
+// alphabetical order.
+func (MessageSummaryCodec) Marshal(contentType string, v any) (string, []byte, error) {
+	var summary MessageSummary
+
+	switch value := v.(type) {
+	case MessageSummary:
+		summary = value
+	case *MessageSummary:
+		summary = *value
+	default:
+		return "", nil, fmt.Errorf("%w: cannot encode %T as %s", message.ErrUnsupportedBody, v, contentType)
+	}
+
+	var buffer bytes.Buffer
+
+	if summary.Waiting {
+		buffer.WriteString("Messages-Waiting: yes\r\n")
+	} else {
+		buffer.WriteString("Messages-Waiting: no\r\n")
+	}
+
+	if summary.Account != "" {
+		buffer.WriteString("Message-Account: " + summary.Account + "\r\n")
+	}
+
+	names := make([]string, 0, len(summary.Messages))
+
+	for name := range summary.Messages {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		count := summary.Messages[name]
+
+		if count.New < 0 || count.Old < 0 || count.NewUrgent < 0 || count.OldUrgent < 0 {
+			return "", nil, fmt.Errorf("%w: %s: negative count for %s", ErrInvalidBody, contentType, name)
+		}
+
+		fmt.Fprintf(&buffer, "%s: %d/%d", canonicalSummaryName(name), count.New, count.Old)
+
+		if count.NewUrgent > 0 || count.OldUrgent > 0 {
+			fmt.Fprintf(&buffer, " (%d/%d)", count.NewUrgent, count.OldUrgent)
+		}
+
+		buffer.WriteString("\r\n")
+	}
+
+	return contentType, buffer.Bytes(), nil
+}
+
+// parseMessageCount parses a message summary line value, such as "2/8 (0/2)", as defined in RFC 3842 section 5.2.
+func parseMessageCount(value string) (MessageCount, error) {
+	var count MessageCount
+
+	counts, urgent, hasUrgent := strings.Cut(value, "(")
+
+	if err := parsePair(counts, &count.New, &count.Old); err != nil {
+		return count, err
+	}
+
+	if hasUrgent {
+		urgent = strings.TrimSpace(urgent)
+
+		if !strings.HasSuffix(urgent, ")") {
+			return count, ErrInvalidBody
+		}
+
+		if err := parsePair(strings.TrimSuffix(urgent, ")"), &count.NewUrgent, &count.OldUrgent); err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
+
+// parsePair parses two counts separated by a slash, such as "2/8", into first and second.
+func parsePair(value string, first *int, second *int) error {
+	left, right, found := strings.Cut(value, "/")
+
+	if !found {
+		return ErrInvalidBody
+	}
+
+	var err error
+
+	if *first, err = strconv.Atoi(strings.TrimSpace(left)); err != nil || *first < 0 {
+		return ErrInvalidBody
+	}
+
+	if *second, err = strconv.Atoi(strings.TrimSpace(right)); err != nil || *second < 0 {
+		return ErrInvalidBody
+	}
+
+	return nil
+}
+
+// canonicalSummaryName returns name with the first letter of each hyphen-separated word in upper case and the rest in
+// lower case, such as "Voice-Message" for "voice-message", since the names of a summary are case-insensitive.
+func canonicalSummaryName(name string) string {
+	words := strings.Split(strings.ToLower(strings.TrimSpace(name)), "-")
+
+	for index, word := range words {
+		if word != "" {
+			words[index] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,6 @@
+package header
+
+import "fmt"
+
+// ErrInvalidHeader when we have an invalid SIP header value.
+var ErrInvalidHeader = fmt.Errorf("invalid SIP header")
@@ -0,0 +1,72 @@
+package header
+
+import (
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/internal/grammar"
+)
+
+// isTokenSequence reports whether value is a sequence of tokens separated by whitespace, which is how a display name
+// may be written without quotes.
+func isTokenSequence(value string) bool {
+	fields := strings.Fields(value)
+
+	if len(fields) == 0 {
+		return false
+	}
+
+	for _, field := range fields {
+		if !grammar.IsToken(field) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// unquote reads the quoted string at the start of payload, returning its content with escapes resolved and the text
+// that follows the closing quote.
+func unquote(payload string) (string, string, error) {
+	if !strings.HasPrefix(payload, `"`) {
+		return "", "", ErrInvalidHeader
+	}
+
+	var builder strings.Builder
+
+	for index := 1; index < len(payload); index++ {
+		switch char := payload[index]; char {
+		case '\\':
+			if index+1 == len(payload) {
+				return "", "", ErrInvalidHeader
+			}
+
+			index++
+			builder.WriteByte(payload[index])
+		case '"':
+			return builder.String(), payload[index+1:], nil
+		default:
+			builder.WriteByte(char)
+		}
+	}
+
+	return "", "", ErrInvalidHeader
+}
+
+// quote returns value as a quoted string, escaping quotes and backslashes.
+func quote(value string) string {
+	var builder strings.Builder
+
+	builder.WriteString(`"`)
+
+	for index := 0; index < len(value); index++ {
+		if char := value[index]; char == '"' || char == '\\' {
+			builder.WriteByte('\\')
+		}
+
+		builder.WriteByte(value[index])
+	}
+
+	builder.WriteString(`"`)
+
+	return builder.String()
+}
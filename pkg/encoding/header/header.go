@@ -0,0 +1,3 @@
+// Package header provides typed representations of SIP header values, as defined in RFC 3261 section 20,
+// and functions for parsing and marshaling them.
+package header
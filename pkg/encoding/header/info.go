@@ -0,0 +1,88 @@
+package header
+
+import (
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/uri"
+)
+
+// Info is a struct that represents a value of the Alert-Info, Call-Info or Error-Info headers, as defined in
+// RFC 3261 sections 20.4, 20.9 and 20.18: a URI of any scheme in angle brackets, followed by parameters, such as
+// "<http://www.example.com/alice/photo.jpg>;purpose=icon".
+type Info struct {
+	// URI is the URI of the information, such as a *uri.GenericURI for an http URI.
+	URI uri.AbsoluteURI
+
+	// Parameters is a map of header parameters, where the key is the parameter name in lower case.
+	Parameters map[string]string
+}
+
+// Purpose returns the value of the purpose parameter of a Call-Info value, such as "icon", "info" or "card", or an
+// empty string if there is none.
+func (info *Info) Purpose() string {
+	return info.Parameters["purpose"]
+}
+
+// UnmarshalInfo parses a value of the Alert-Info, Call-Info or Error-Info headers into the Info struct.
+// It returns an error if the value is invalid or the pointer is nil.
+func UnmarshalInfo(payload string, info *Info) error {
+	if info == nil {
+		return ErrInvalidHeader
+	}
+
+	*info = Info{}
+
+	spec, params, err := unmarshalAngleBrackets(strings.TrimSpace(payload))
+
+	if err != nil {
+		return err
+	}
+
+	address, err := uri.Parse(spec)
+
+	if err != nil {
+		return ErrInvalidHeader
+	}
+
+	params = strings.TrimSpace(params)
+
+	if params != "" && !strings.HasPrefix(params, ";") {
+		return ErrInvalidHeader
+	}
+
+	parameters, err := unmarshalParams(strings.TrimPrefix(params, ";"))
+
+	if err != nil {
+		return err
+	}
+
+	info.URI = address
+	info.Parameters = parameters
+
+	return nil
+}
+
+// MarshalInfo takes an Info struct and converts it to its string representation, with the URI in angle brackets.
+// It returns ErrInvalidHeader if the Info or its URI is nil, and the error of uri.Marshal or uri.MarshalTel if its
+// URI is invalid.
+func MarshalInfo(info *Info) (string, error) {
+	if info == nil || info.URI == nil {
+		return "", ErrInvalidHeader
+	}
+
+	address, err := marshalAddress(info.URI)
+
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("<")
+	builder.WriteString(address)
+	builder.WriteString(">")
+
+	marshalParams(&builder, info.Parameters)
+
+	return builder.String(), nil
+}
@@ -0,0 +1,118 @@
+package header
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/otoru/party/pkg/encoding/uri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalInfoWithValidCases(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		expected *Info
+	}{
+		{
+			name:    "Test Call-Info with purpose",
+			payload: "<http://wwww.example.com/alice/photo.jpg> ;purpose=icon",
+			expected: &Info{
+				URI:        &uri.GenericURI{URL: url.URL{Scheme: "http", Host: "wwww.example.com", Path: "/alice/photo.jpg"}},
+				Parameters: map[string]string{"purpose": "icon"},
+			},
+		},
+		{
+			name:    "Test Alert-Info without parameters",
+			payload: "<http://www.example.com/sounds/moo.wav>",
+			expected: &Info{
+				URI:        &uri.GenericURI{URL: url.URL{Scheme: "http", Host: "www.example.com", Path: "/sounds/moo.wav"}},
+				Parameters: map[string]string{},
+			},
+		},
+		{
+			name:    "Test Error-Info with SIP URI",
+			payload: "<sip:not-in-service-recording@atlanta.com>",
+			expected: &Info{
+				URI: &uri.URI{
+					Scheme:     "sip",
+					User:       "not-in-service-recording",
+					Host:       "atlanta.com",
+					Parameters: map[string]string{},
+					Headers:    map[string]string{},
+				},
+				Parameters: map[string]string{},
+			},
+		},
+		{
+			name:    "Test Call-Info with URN",
+			payload: "<urn:service:sos>;purpose=emergency-CallId",
+			expected: &Info{
+				URI:        &uri.URN{NID: "service", NSS: "sos"},
+				Parameters: map[string]string{"purpose": "emergency-CallId"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			info := new(Info)
+
+			err := UnmarshalInfo(tc.payload, info)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, info)
+		})
+	}
+}
+
+func TestUnmarshalInfoWithInvalidCases(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		info    *Info
+	}{
+		{name: "Test URI without angle brackets", payload: "http://www.example.com/sounds/moo.wav", info: new(Info)},
+		{name: "Test URI without scheme", payload: "<bellcore-dr2>", info: new(Info)},
+		{name: "Test unterminated angle bracket", payload: "<http://www.example.com;purpose=icon", info: new(Info)},
+		{name: "Test text after angle bracket", payload: "<http://www.example.com> purpose=icon", info: new(Info)},
+		{name: "Test without Info instance", payload: "<http://www.example.com>", info: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := UnmarshalInfo(tc.payload, tc.info)
+
+			assert.ErrorIs(t, err, ErrInvalidHeader)
+		})
+	}
+}
+
+func TestMarshalInfo(t *testing.T) {
+	info := new(Info)
+
+	assert.NoError(t, UnmarshalInfo("<http://www.example.com/alice/> ;purpose=info", info))
+	assert.Equal(t, "info", info.Purpose())
+
+	result, err := MarshalInfo(info)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<http://www.example.com/alice/>;purpose=info", result)
+
+	for _, invalid := range []*Info{nil, {}} {
+		result, err := MarshalInfo(invalid)
+
+		assert.Empty(t, result)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	}
+
+	result, err = MarshalInfo(&Info{URI: &uri.URI{Scheme: "sip"}})
+
+	assert.Empty(t, result)
+	assert.ErrorIs(t, err, uri.ErrInvalidSipURI)
+
+	result, err = MarshalInfo(&Info{URI: &uri.TelURI{Number: "7042"}})
+
+	assert.Empty(t, result)
+	assert.ErrorIs(t, err, uri.ErrInvalidTelURI)
+}
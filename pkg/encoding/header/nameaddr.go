@@ -0,0 +1,191 @@
+package header
+
+import (
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/uri"
+)
+
+// NameAddr is a struct that represents a name-addr or addr-spec header value, as used by the From, To, Contact,
+// Refer-To, Reply-To and P-Asserted-Identity headers and defined in RFC 3261 section 20.
+//
+// The URI is embedded, so its fields can be accessed directly. Header parameters, such as tag, expires or q, are
+// kept in Parameters, while URI parameters remain in URI.Parameters. A URI of a scheme other than sip and sips,
+// such as tel, urn or mailto, is kept in Other instead.
+type NameAddr struct {
+	// DisplayName is the display name, without surrounding quotes and with escapes resolved, which may be empty.
+	DisplayName string
+
+	uri.URI
+
+	// Other is the URI when its scheme is neither sip nor sips, in which case the embedded URI is left empty.
+	Other uri.AbsoluteURI
+
+	// Parameters is a map of header parameters, where the key is the parameter name in lower case.
+	Parameters map[string]string
+}
+
+// Tag returns the value of the tag parameter, which identifies a dialog, or an empty string if there is none.
+func (addr *NameAddr) Tag() string {
+	return addr.Parameters["tag"]
+}
+
+// Address returns the URI of addr whatever its scheme: Other when it is set, and the embedded URI otherwise.
+func (addr *NameAddr) Address() uri.AbsoluteURI {
+	if addr.Other != nil {
+		return addr.Other
+	}
+
+	return &addr.URI
+}
+
+// UnmarshalNameAddr parses a name-addr, such as `"Alice" <sip:alice@atlanta.com>;tag=1928301774`, or an addr-spec,
+// such as "sip:alice@atlanta.com;tag=1928301774", into the NameAddr struct.
+//
+// Parameters that follow the closing angle bracket of a name-addr, or the URI of an addr-spec, are header
+// parameters. It returns an error if the value is invalid or the pointer is nil.
+func UnmarshalNameAddr(payload string, addr *NameAddr) error {
+	if addr == nil {
+		return ErrInvalidHeader
+	}
+
+	*addr = NameAddr{}
+
+	payload = strings.TrimSpace(payload)
+
+	var spec string
+	var params string
+
+	switch start := strings.Index(payload, "<"); {
+	case strings.HasPrefix(payload, `"`):
+		name, rest, err := unquote(payload)
+
+		if err != nil {
+			return err
+		}
+
+		addr.DisplayName = name
+
+		if spec, params, err = unmarshalAngleBrackets(strings.TrimSpace(rest)); err != nil {
+			return err
+		}
+	case start >= 0:
+		name := strings.TrimSpace(payload[:start])
+
+		if name != "" && !isTokenSequence(name) {
+			return ErrInvalidHeader
+		}
+
+		addr.DisplayName = strings.Join(strings.Fields(name), " ")
+
+		var err error
+
+		if spec, params, err = unmarshalAngleBrackets(payload[start:]); err != nil {
+			return err
+		}
+	default:
+		spec, params, _ = strings.Cut(payload, ";")
+		spec = strings.TrimSpace(spec)
+
+		// As required by RFC 3261 section 20.10, a URI with a comma or question mark must be in angle brackets
+		if strings.ContainsAny(spec, ",?") {
+			return ErrInvalidHeader
+		}
+
+		if params != "" {
+			params = ";" + params
+		}
+	}
+
+	address, err := uri.Parse(spec)
+
+	if err != nil {
+		return ErrInvalidHeader
+	}
+
+	if sip, ok := address.(*uri.URI); ok {
+		addr.URI = *sip
+	} else {
+		addr.Other = address
+	}
+
+	params = strings.TrimSpace(params)
+
+	if params != "" && !strings.HasPrefix(params, ";") {
+		return ErrInvalidHeader
+	}
+
+	parameters, err := unmarshalParams(strings.TrimPrefix(params, ";"))
+
+	if err != nil {
+		return err
+	}
+
+	addr.Parameters = parameters
+
+	return nil
+}
+
+// MarshalNameAddr takes a NameAddr struct and converts it to its name-addr string representation, quoting the
+// display name when it is not a sequence of tokens. It returns ErrInvalidHeader if the NameAddr is nil, and the error
+// of uri.Marshal or uri.MarshalTel if its URI is invalid.
+func MarshalNameAddr(addr *NameAddr) (string, error) {
+	if addr == nil {
+		return "", ErrInvalidHeader
+	}
+
+	spec, err := marshalAddress(addr.Address())
+
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+
+	if addr.DisplayName != "" {
+		if isTokenSequence(addr.DisplayName) {
+			builder.WriteString(addr.DisplayName)
+		} else {
+			builder.WriteString(quote(addr.DisplayName))
+		}
+
+		builder.WriteString(" ")
+	}
+
+	builder.WriteString("<")
+	builder.WriteString(spec)
+	builder.WriteString(">")
+
+	marshalParams(&builder, addr.Parameters)
+
+	return builder.String(), nil
+}
+
+// marshalAddress returns the string representation of address, with the error of its marshaller for SIP, SIPS and
+// tel URIs, whose String methods fall back to a partial representation when they are invalid.
+func marshalAddress(address uri.AbsoluteURI) (string, error) {
+	switch value := address.(type) {
+	case *uri.URI:
+		return uri.Marshal(value)
+	case *uri.TelURI:
+		return uri.MarshalTel(value)
+	}
+
+	return address.String(), nil
+}
+
+// unmarshalAngleBrackets splits a value such as "<sip:alice@atlanta.com>;tag=1" into the URI between the angle
+// brackets and the text that follows them.
+func unmarshalAngleBrackets(payload string) (string, string, error) {
+	if !strings.HasPrefix(payload, "<") {
+		return "", "", ErrInvalidHeader
+	}
+
+	end := strings.Index(payload, ">")
+
+	if end < 0 {
+		return "", "", ErrInvalidHeader
+	}
+
+	return payload[1:end], payload[end+1:], nil
+}
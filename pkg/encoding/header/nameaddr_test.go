@@ -0,0 +1,288 @@
+package header
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/otoru/party/pkg/encoding/uri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalNameAddrWithValidCases(t *testing.T) {
+	tests := []struct {
+		name         string
+		payload      string
+		expectedAddr *NameAddr
+	}{
+		{
+			name:    "Test name-addr with token display name and tag",
+			payload: "Alice <sip:alice@atlanta.com>;tag=1928301774",
+			expectedAddr: &NameAddr{
+				DisplayName: "Alice",
+				URI: uri.URI{
+					Scheme:     "sip",
+					User:       "alice",
+					Host:       "atlanta.com",
+					Parameters: map[string]string{},
+					Headers:    map[string]string{},
+				},
+				Parameters: map[string]string{
+					"tag": "1928301774",
+				},
+			},
+		},
+		{
+			name:    "Test name-addr with quoted display name",
+			payload: `"Doe, John \"JD\"" <sip:john@biloxi.com;transport=tcp>;tag=a6c85cf;x-extra`,
+			expectedAddr: &NameAddr{
+				DisplayName: `Doe, John "JD"`,
+				URI: uri.URI{
+					Scheme: "sip",
+					User:   "john",
+					Host:   "biloxi.com",
+					Parameters: map[string]string{
+						"transport": "tcp",
+					},
+					Headers: map[string]string{},
+				},
+				Parameters: map[string]string{
+					"tag":     "a6c85cf",
+					"x-extra": "",
+				},
+			},
+		},
+		{
+			name:    "Test name-addr without display name",
+			payload: "<sip:alice@pc33.atlanta.com:5060;lr>;expires=3600;q=0.7",
+			expectedAddr: &NameAddr{
+				URI: uri.URI{
+					Scheme: "sip",
+					User:   "alice",
+					Host:   "pc33.atlanta.com",
+					Port:   5060,
+					Parameters: map[string]string{
+						"lr": "",
+					},
+					Headers: map[string]string{},
+				},
+				Parameters: map[string]string{
+					"expires": "3600",
+					"q":       "0.7",
+				},
+			},
+		},
+		{
+			name:    "Test addr-spec with header parameters",
+			payload: "sip:bob@biloxi.com;tag=8321234356",
+			expectedAddr: &NameAddr{
+				URI: uri.URI{
+					Scheme:     "sip",
+					User:       "bob",
+					Host:       "biloxi.com",
+					Parameters: map[string]string{},
+					Headers:    map[string]string{},
+				},
+				Parameters: map[string]string{
+					"tag": "8321234356",
+				},
+			},
+		},
+		{
+			name:    "Test addr-spec with whitespace before header parameters",
+			payload: "sip:vivekg@chair-dnrc.example.com ;   tag    = 1918181833n",
+			expectedAddr: &NameAddr{
+				URI: uri.URI{
+					Scheme:     "sip",
+					User:       "vivekg",
+					Host:       "chair-dnrc.example.com",
+					Parameters: map[string]string{},
+					Headers:    map[string]string{},
+				},
+				Parameters: map[string]string{
+					"tag": "1918181833n",
+				},
+			},
+		},
+		{
+			name:    "Test name-addr without whitespace before angle bracket",
+			payload: "caller<sip:caller@example.com>;tag=323",
+			expectedAddr: &NameAddr{
+				DisplayName: "caller",
+				URI: uri.URI{
+					Scheme:     "sip",
+					User:       "caller",
+					Host:       "example.com",
+					Parameters: map[string]string{},
+					Headers:    map[string]string{},
+				},
+				Parameters: map[string]string{
+					"tag": "323",
+				},
+			},
+		},
+		{
+			name:    "Test name-addr with tel URI",
+			payload: "<tel:+1-201-555-0123;ext=22>;tag=887s",
+			expectedAddr: &NameAddr{
+				Other:      &uri.TelURI{Number: "+1-201-555-0123", Extension: "22", Parameters: map[string]string{}},
+				Parameters: map[string]string{"tag": "887s"},
+			},
+		},
+		{
+			name:    "Test name-addr with service URN",
+			payload: "Emergency <urn:service:sos>",
+			expectedAddr: &NameAddr{
+				DisplayName: "Emergency",
+				Other:       &uri.URN{NID: "service", NSS: "sos"},
+				Parameters:  map[string]string{},
+			},
+		},
+		{
+			name:    "Test addr-spec with mailto URI",
+			payload: "mailto:alice@example.com;expires=60",
+			expectedAddr: &NameAddr{
+				Other:      &uri.GenericURI{URL: url.URL{Scheme: "mailto", Opaque: "alice@example.com"}},
+				Parameters: map[string]string{"expires": "60"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			addr := new(NameAddr)
+
+			err := UnmarshalNameAddr(tc.payload, addr)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedAddr, addr)
+		})
+	}
+}
+
+func TestUnmarshalNameAddrWithInvalidCases(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		addr    *NameAddr
+	}{
+		{name: "Test unterminated quoted display name", payload: `"Mr. J. User <sip:j.user@example.com>`, addr: new(NameAddr)},
+		{name: "Test non-token display name", payload: "Bell, Alexander <sip:a.g.bell@example.com>;tag=43", addr: new(NameAddr)},
+		{name: "Test unterminated angle bracket", payload: "<sip:alice@atlanta.com;tag=1", addr: new(NameAddr)},
+		{name: "Test text after angle bracket", payload: "<sip:alice@atlanta.com> tag=1", addr: new(NameAddr)},
+		{name: "Test missing scheme", payload: "<alice.atlanta.com>", addr: new(NameAddr)},
+		{name: "Test addr-spec with URI headers", payload: "sip:user@example.com?Route=%3Csip:sip.example.com%3E", addr: new(NameAddr)},
+		{name: "Test whitespace inside angle brackets", payload: `"Watson, Thomas" < sip:t.watson@example.org >`, addr: new(NameAddr)},
+		{name: "Test invalid tel URI", payload: "<tel:7042>", addr: new(NameAddr)},
+		{name: "Test invalid URN", payload: "<urn:x:>", addr: new(NameAddr)},
+		{name: "Test without NameAddr instance", payload: "<sip:alice@atlanta.com>", addr: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("Payload: %s", tc.payload)
+
+			err := UnmarshalNameAddr(tc.payload, tc.addr)
+
+			assert.ErrorIs(t, err, ErrInvalidHeader)
+		})
+	}
+}
+
+func TestMarshalNameAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		addr     *NameAddr
+		expected string
+	}{
+		{
+			name: "Test name-addr with token display name",
+			addr: &NameAddr{
+				DisplayName: "Alice",
+				URI:         uri.URI{Scheme: "sip", User: "alice", Host: "atlanta.com"},
+				Parameters:  map[string]string{"tag": "1928301774"},
+			},
+			expected: "Alice <sip:alice@atlanta.com>;tag=1928301774",
+		},
+		{
+			name: "Test name-addr with quoted display name",
+			addr: &NameAddr{
+				DisplayName: `Doe, John "JD"`,
+				URI:         uri.URI{Scheme: "sip", User: "john", Host: "biloxi.com", Parameters: map[string]string{"transport": "tcp"}},
+				Parameters:  map[string]string{"tag": "a6c85cf", "expires": "60"},
+			},
+			expected: `"Doe, John \"JD\"" <sip:john@biloxi.com;transport=tcp>;expires=60;tag=a6c85cf`,
+		},
+		{
+			name:     "Test name-addr without display name",
+			addr:     &NameAddr{URI: uri.URI{Scheme: "sip", Host: "biloxi.com"}},
+			expected: "<sip:biloxi.com>",
+		},
+		{
+			name:     "Test name-addr with tel URI",
+			addr:     &NameAddr{DisplayName: "Bob", Other: &uri.TelURI{Number: "+15551234567"}},
+			expected: "Bob <tel:+15551234567>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := MarshalNameAddr(tc.addr)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("Test nil NameAddr", func(t *testing.T) {
+		result, err := MarshalNameAddr(nil)
+
+		assert.Empty(t, result)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+
+	t.Run("Test invalid URI", func(t *testing.T) {
+		result, err := MarshalNameAddr(&NameAddr{DisplayName: "Bob"})
+
+		assert.Empty(t, result)
+		assert.ErrorIs(t, err, uri.ErrInvalidSipURI)
+
+		result, err = MarshalNameAddr(&NameAddr{Other: &uri.TelURI{Number: "+1-555-CALL"}})
+
+		assert.Empty(t, result)
+		assert.ErrorIs(t, err, uri.ErrInvalidTelURI)
+	})
+}
+
+func TestNameAddrTag(t *testing.T) {
+	addr := new(NameAddr)
+
+	assert.NoError(t, UnmarshalNameAddr("Bob <sip:bob@biloxi.com;tag=uri>;tag=a6c85cf", addr))
+	assert.Equal(t, "a6c85cf", addr.Tag())
+	assert.Equal(t, "uri", addr.URI.Parameters["tag"])
+}
+
+func TestNameAddrAddress(t *testing.T) {
+	tests := []struct {
+		payload string
+		scheme  string
+		want    string
+	}{
+		{payload: "<sip:alice@atlanta.com;transport=tcp>", scheme: "sip", want: "sip:alice@atlanta.com;transport=tcp"},
+		{payload: "<SIPS:bob@biloxi.com>", scheme: "sips", want: "SIPS:bob@biloxi.com"},
+		{payload: "<tel:7042;phone-context=example.com>", scheme: "tel", want: "tel:7042;phone-context=example.com"},
+		{payload: "<urn:service:sos.fire>", scheme: "urn", want: "urn:service:sos.fire"},
+		{payload: "<im:alice@example.com>", scheme: "im", want: "im:alice@example.com"},
+		{payload: "<http://www.example.com>", scheme: "http", want: "http://www.example.com"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.payload, func(t *testing.T) {
+			addr := new(NameAddr)
+
+			assert.NoError(t, UnmarshalNameAddr(tc.payload, addr))
+
+			assert.Equal(t, tc.scheme, addr.Address().SchemeName())
+			assert.Equal(t, tc.want, addr.Address().String())
+		})
+	}
+}
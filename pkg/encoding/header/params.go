@@ -0,0 +1,60 @@
+package header
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/internal/grammar"
+)
+
+// unmarshalParams parses a list of semicolon-separated parameters, such as "branch=z9hG4bK776;rport", into a map
+// keyed by the parameter name in lower case. Parameters without a value are stored with an empty value.
+func unmarshalParams(payload string) (map[string]string, error) {
+	params := make(map[string]string)
+
+	if strings.TrimSpace(payload) == "" {
+		return params, nil
+	}
+
+	parts, _ := grammar.Split(payload, ';')
+
+	for _, param := range parts {
+		name, value, _ := strings.Cut(param, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+
+		if name == "" {
+			return nil, ErrInvalidHeader
+		}
+
+		params[name] = value
+	}
+
+	return params, nil
+}
+
+// marshalParams writes params to builder, each preceded by a semicolon, in alphabetical order.
+func marshalParams(builder *strings.Builder, params map[string]string) {
+	names := make([]string, 0, len(params))
+
+	for name := range params {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		marshalParam(builder, name, params[name])
+	}
+}
+
+// marshalParam writes a single parameter to builder, preceded by a semicolon.
+func marshalParam(builder *strings.Builder, name string, value string) {
+	builder.WriteString(";")
+	builder.WriteString(name)
+
+	if value != "" {
+		builder.WriteString("=")
+		builder.WriteString(value)
+	}
+}
@@ -0,0 +1,250 @@
+package header
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/internal/grammar"
+)
+
+// Via is a struct that represents a single value of the Via header, as defined in RFC 3261 section 20.42.
+type Via struct {
+	// Protocol is the name of the protocol, usually "SIP".
+	Protocol string
+
+	// Version is the version of the protocol, usually "2.0".
+	Version string
+
+	// Transport is the transport used to send the request, such as "UDP", "TCP", "TLS" or "WS".
+	Transport string
+
+	// Host is the domain name or IP address of the sender, without brackets for IPv6 references.
+	Host string
+
+	// Port is the port of the sender, which may be 0 if not specified.
+	Port int
+
+	// Branch is the transaction identifier, which starts with the "z9hG4bK" magic cookie for RFC 3261 compliant
+	// implementations.
+	Branch string
+
+	// Received is the source IP address from which the request was received, added by the server, without brackets
+	// for IPv6 addresses.
+	Received string
+
+	// RPort is the source port from which the request was received, added by the server as defined in RFC 3581.
+	RPort int
+
+	// HasRPort reports whether the rport parameter is present, with or without a value. A client sets it without
+	// a value to ask the server to fill in RPort.
+	HasRPort bool
+
+	// MAddr is the multicast address to which responses should be sent.
+	MAddr string
+
+	// TTL is the time-to-live of multicast requests, which may be 0 if not specified.
+	TTL int
+
+	// Parameters is a map of any other Via parameters, where the key is the parameter name in lower case.
+	Parameters map[string]string
+}
+
+// MagicCookie is the prefix of branch parameters generated by implementations compliant with RFC 3261.
+const MagicCookie = "z9hG4bK"
+
+// UnmarshalVia parses a single Via header value, such as "SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds",
+// into the Via struct. It returns an error if the value is invalid or the pointer is nil.
+func UnmarshalVia(payload string, via *Via) error {
+	if via == nil {
+		return ErrInvalidHeader
+	}
+
+	*via = Via{}
+
+	parts, _ := grammar.Split(payload, ';')
+
+	protocol := strings.SplitN(parts[0], "/", 3)
+
+	if len(protocol) != 3 {
+		return ErrInvalidHeader
+	}
+
+	via.Protocol = strings.TrimSpace(protocol[0])
+	via.Version = strings.TrimSpace(protocol[1])
+
+	fields := strings.Fields(protocol[2])
+
+	if via.Protocol == "" || via.Version == "" || len(fields) < 2 {
+		return ErrInvalidHeader
+	}
+
+	via.Transport = strings.ToUpper(fields[0])
+
+	host, port, err := unmarshalHostPort(strings.Join(fields[1:], ""))
+
+	if err != nil {
+		return err
+	}
+
+	via.Host = host
+	via.Port = port
+
+	params, err := unmarshalParams(strings.Join(parts[1:], ";"))
+
+	if err != nil {
+		return err
+	}
+
+	if value, ok := params["branch"]; ok {
+		via.Branch = value
+		delete(params, "branch")
+	}
+
+	if value, ok := params["received"]; ok {
+		// RFC 5118 section 4.5 asks for IPv6 addresses to be accepted with and without brackets
+		if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+			value = value[1 : len(value)-1]
+		}
+
+		if net.ParseIP(value) == nil {
+			return ErrInvalidHeader
+		}
+
+		via.Received = value
+		delete(params, "received")
+	}
+
+	if value, ok := params["rport"]; ok {
+		if value != "" {
+			if via.RPort, err = strconv.Atoi(value); err != nil || via.RPort < 1 || via.RPort > 65535 {
+				return ErrInvalidHeader
+			}
+		}
+
+		via.HasRPort = true
+		delete(params, "rport")
+	}
+
+	if value, ok := params["maddr"]; ok {
+		via.MAddr = value
+		delete(params, "maddr")
+	}
+
+	if value, ok := params["ttl"]; ok {
+		if via.TTL, err = strconv.Atoi(value); err != nil || via.TTL < 0 || via.TTL > 255 {
+			return ErrInvalidHeader
+		}
+
+		delete(params, "ttl")
+	}
+
+	via.Parameters = params
+
+	return nil
+}
+
+// MarshalVia takes a Via struct and converts it to its string representation.
+// It returns an error if the Via is invalid or nil.
+func MarshalVia(via *Via) (string, error) {
+	if via == nil || via.Protocol == "" || via.Version == "" || via.Transport == "" || via.Host == "" {
+		return "", ErrInvalidHeader
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(via.Protocol)
+	builder.WriteString("/")
+	builder.WriteString(via.Version)
+	builder.WriteString("/")
+	builder.WriteString(via.Transport)
+	builder.WriteString(" ")
+	builder.WriteString(marshalHostPort(via.Host, via.Port))
+
+	if via.Branch != "" {
+		marshalParam(&builder, "branch", via.Branch)
+	}
+
+	if via.Received != "" {
+		marshalParam(&builder, "received", via.Received)
+	}
+
+	if via.RPort != 0 {
+		marshalParam(&builder, "rport", strconv.Itoa(via.RPort))
+	} else if via.HasRPort {
+		marshalParam(&builder, "rport", "")
+	}
+
+	if via.MAddr != "" {
+		marshalParam(&builder, "maddr", via.MAddr)
+	}
+
+	if via.TTL != 0 {
+		marshalParam(&builder, "ttl", strconv.Itoa(via.TTL))
+	}
+
+	marshalParams(&builder, via.Parameters)
+
+	return builder.String(), nil
+}
+
+// unmarshalHostPort splits a host and optional port, such as "pc33.atlanta.com:5060" or "[2001:db8::1]:5060".
+func unmarshalHostPort(payload string) (string, int, error) {
+	host := payload
+	port := ""
+
+	if strings.HasPrefix(payload, "[") {
+		end := strings.Index(payload, "]")
+
+		if end < 0 {
+			return "", 0, ErrInvalidHeader
+		}
+
+		host = payload[1:end]
+		rest := payload[end+1:]
+
+		if rest != "" {
+			if !strings.HasPrefix(rest, ":") {
+				return "", 0, ErrInvalidHeader
+			}
+
+			port = rest[1:]
+		}
+
+		if net.ParseIP(host) == nil || !strings.Contains(host, ":") {
+			return "", 0, ErrInvalidHeader
+		}
+	} else if index := strings.Index(payload, ":"); index >= 0 {
+		host = payload[:index]
+		port = payload[index+1:]
+	}
+
+	if host == "" {
+		return "", 0, ErrInvalidHeader
+	}
+
+	if port == "" {
+		return host, 0, nil
+	}
+
+	number, err := strconv.Atoi(port)
+
+	if err != nil || number < 1 || number > 65535 {
+		return "", 0, ErrInvalidHeader
+	}
+
+	return host, number, nil
+}
+
+// marshalHostPort joins a host and optional port, restoring the brackets of IPv6 references.
+func marshalHostPort(host string, port int) string {
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	if port == 0 {
+		return host
+	}
+
+	return host + ":" + strconv.Itoa(port)
+}
@@ -0,0 +1,258 @@
+package header
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalViaWithValidCases(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     string
+		expectedVia *Via
+	}{
+		{
+			name:    "Test minimal Via",
+			payload: "SIP/2.0/UDP pc33.atlanta.com",
+			expectedVia: &Via{
+				Protocol:   "SIP",
+				Version:    "2.0",
+				Transport:  "UDP",
+				Host:       "pc33.atlanta.com",
+				Parameters: map[string]string{},
+			},
+		},
+		{
+			name:    "Test Via with port and branch",
+			payload: "SIP/2.0/TCP client.atlanta.example.com:5060;branch=z9hG4bK74bf9",
+			expectedVia: &Via{
+				Protocol:   "SIP",
+				Version:    "2.0",
+				Transport:  "TCP",
+				Host:       "client.atlanta.example.com",
+				Port:       5060,
+				Branch:     "z9hG4bK74bf9",
+				Parameters: map[string]string{},
+			},
+		},
+		{
+			name:    "Test Via with received and rport",
+			payload: "SIP/2.0/UDP bobspc.biloxi.com:5060;branch=z9hG4bKnashds7;received=192.0.2.4;rport=9988",
+			expectedVia: &Via{
+				Protocol:   "SIP",
+				Version:    "2.0",
+				Transport:  "UDP",
+				Host:       "bobspc.biloxi.com",
+				Port:       5060,
+				Branch:     "z9hG4bKnashds7",
+				Received:   "192.0.2.4",
+				RPort:      9988,
+				HasRPort:   true,
+				Parameters: map[string]string{},
+			},
+		},
+		{
+			name:    "Test Via with bracketed IPv6 received",
+			payload: "SIP/2.0/UDP [2001:db8::9:1];branch=z9hG4bKas3-111;received=[2001:db8::9:255]",
+			expectedVia: &Via{
+				Protocol:   "SIP",
+				Version:    "2.0",
+				Transport:  "UDP",
+				Host:       "2001:db8::9:1",
+				Branch:     "z9hG4bKas3-111",
+				Received:   "2001:db8::9:255",
+				Parameters: map[string]string{},
+			},
+		},
+		{
+			name:    "Test Via with IPv4-mapped IPv6 host",
+			payload: "SIP/2.0/UDP [::ffff:192.0.2.10]:19823;branch=z9hG4bKbh19",
+			expectedVia: &Via{
+				Protocol:   "SIP",
+				Version:    "2.0",
+				Transport:  "UDP",
+				Host:       "::ffff:192.0.2.10",
+				Port:       19823,
+				Branch:     "z9hG4bKbh19",
+				Parameters: map[string]string{},
+			},
+		},
+		{
+			name:    "Test Via with valueless rport",
+			payload: "SIP/2.0/UDP 10.1.1.1:4540;rport;branch=z9hG4bK1425",
+			expectedVia: &Via{
+				Protocol:   "SIP",
+				Version:    "2.0",
+				Transport:  "UDP",
+				Host:       "10.1.1.1",
+				Port:       4540,
+				Branch:     "z9hG4bK1425",
+				HasRPort:   true,
+				Parameters: map[string]string{},
+			},
+		},
+		{
+			name:    "Test Via with maddr, ttl and extension parameters",
+			payload: "SIP/2.0/UDP first.example.com:4000;ttl=16;maddr=224.2.0.1;branch=z9hG4bKa7c6a8dlze.1;alias",
+			expectedVia: &Via{
+				Protocol:  "SIP",
+				Version:   "2.0",
+				Transport: "UDP",
+				Host:      "first.example.com",
+				Port:      4000,
+				Branch:    "z9hG4bKa7c6a8dlze.1",
+				MAddr:     "224.2.0.1",
+				TTL:       16,
+				Parameters: map[string]string{
+					"alias": "",
+				},
+			},
+		},
+		{
+			name:    "Test Via with linear whitespace",
+			payload: "SIP  /   2.0  / udp   192.0.2.2 : 5060 ;  BRANCH = 390skdjuw",
+			expectedVia: &Via{
+				Protocol:   "SIP",
+				Version:    "2.0",
+				Transport:  "UDP",
+				Host:       "192.0.2.2",
+				Port:       5060,
+				Branch:     "390skdjuw",
+				Parameters: map[string]string{},
+			},
+		},
+		{
+			name:    "Test Via with IPv6 reference",
+			payload: "SIP/2.0/UDP [2001:db8::9:1]:6050;branch=z9hG4bKas3-111",
+			expectedVia: &Via{
+				Protocol:   "SIP",
+				Version:    "2.0",
+				Transport:  "UDP",
+				Host:       "2001:db8::9:1",
+				Port:       6050,
+				Branch:     "z9hG4bKas3-111",
+				Parameters: map[string]string{},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			via := new(Via)
+
+			err := UnmarshalVia(tc.payload, via)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedVia, via)
+		})
+	}
+}
+
+func TestUnmarshalViaWithInvalidCases(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		via     *Via
+	}{
+		{name: "Test empty payload", payload: "", via: new(Via)},
+		{name: "Test missing transport", payload: "SIP/2.0 pc33.atlanta.com", via: new(Via)},
+		{name: "Test missing sent-by", payload: "SIP/2.0/UDP", via: new(Via)},
+		{name: "Test invalid port", payload: "SIP/2.0/UDP pc33.atlanta.com:port", via: new(Via)},
+		{name: "Test unterminated IPv6 reference", payload: "SIP/2.0/UDP [2001:db8::9:1:6050", via: new(Via)},
+		{name: "Test invalid received", payload: "SIP/2.0/UDP pc33.atlanta.com;received=atlanta", via: new(Via)},
+		{name: "Test invalid rport", payload: "SIP/2.0/UDP pc33.atlanta.com;rport=70000", via: new(Via)},
+		{name: "Test invalid ttl", payload: "SIP/2.0/UDP pc33.atlanta.com;ttl=256", via: new(Via)},
+		{name: "Test without Via instance", payload: "SIP/2.0/UDP pc33.atlanta.com", via: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("Payload: %s", tc.payload)
+
+			err := UnmarshalVia(tc.payload, tc.via)
+
+			assert.ErrorIs(t, err, ErrInvalidHeader)
+		})
+	}
+}
+
+func TestMarshalViaWithValidCases(t *testing.T) {
+	tests := []struct {
+		name     string
+		via      *Via
+		expected string
+	}{
+		{
+			name: "Test minimal Via",
+			via: &Via{
+				Protocol:  "SIP",
+				Version:   "2.0",
+				Transport: "UDP",
+				Host:      "pc33.atlanta.com",
+			},
+			expected: "SIP/2.0/UDP pc33.atlanta.com",
+		},
+		{
+			name: "Test full Via",
+			via: &Via{
+				Protocol:  "SIP",
+				Version:   "2.0",
+				Transport: "UDP",
+				Host:      "first.example.com",
+				Port:      4000,
+				Branch:    "z9hG4bKa7c6a8dlze.1",
+				Received:  "192.0.2.1",
+				RPort:     4001,
+				MAddr:     "224.2.0.1",
+				TTL:       16,
+				Parameters: map[string]string{
+					"keep":  "",
+					"alias": "",
+				},
+			},
+			expected: "SIP/2.0/UDP first.example.com:4000;branch=z9hG4bKa7c6a8dlze.1;received=192.0.2.1;rport=4001;maddr=224.2.0.1;ttl=16;alias;keep",
+		},
+		{
+			name: "Test Via with valueless rport and IPv6 reference",
+			via: &Via{
+				Protocol:  "SIP",
+				Version:   "2.0",
+				Transport: "TCP",
+				Host:      "2001:db8::9:1",
+				Port:      6050,
+				Branch:    "z9hG4bKas3-111",
+				HasRPort:  true,
+			},
+			expected: "SIP/2.0/TCP [2001:db8::9:1]:6050;branch=z9hG4bKas3-111;rport",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := MarshalVia(tc.via)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestMarshalViaWithInvalidCases(t *testing.T) {
+	tests := []struct {
+		name string
+		via  *Via
+	}{
+		{name: "Test nil Via", via: nil},
+		{name: "Test Via without host", via: &Via{Protocol: "SIP", Version: "2.0", Transport: "UDP"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := MarshalVia(tc.via)
+
+			assert.Empty(t, result)
+			assert.ErrorIs(t, err, ErrInvalidHeader)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Package grammar holds the core rules of the SIP grammar, as defined in RFC 3261 section 25.1, that are shared by the
+// uri, header and message packages.
+package grammar
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrUnterminatedQuote when a quoted string has no closing quote.
+	ErrUnterminatedQuote = errors.New("unterminated quoted string")
+
+	// ErrUnterminatedBracket when an angle bracket has no closing bracket.
+	ErrUnterminatedBracket = errors.New("unterminated angle bracket")
+
+	// ErrNestedBracket when an angle bracket is opened inside another one.
+	ErrNestedBracket = errors.New("nested angle bracket")
+)
+
+// IsTokenChar reports whether char is allowed in a token.
+func IsTokenChar(char byte) bool {
+	switch {
+	case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		return true
+	default:
+		return strings.IndexByte("-.!%*_+`'~", char) >= 0
+	}
+}
+
+// IsToken reports whether value is a non-empty token.
+func IsToken(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for index := 0; index < len(value); index++ {
+		if !IsTokenChar(value[index]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsHex reports whether char is a hexadecimal digit.
+func IsHex(char byte) bool {
+	return char >= '0' && char <= '9' || char >= 'a' && char <= 'f' || char >= 'A' && char <= 'F'
+}
+
+// Split splits value around each separator that is not inside a quoted string or angle brackets, such as the commas
+// between the values of a header or the semicolons between parameters.
+//
+// The parts are always returned, along with ErrNestedBracket, ErrUnterminatedQuote or ErrUnterminatedBracket when
+// value is not well formed, so that callers may choose to ignore the error.
+func Split(value string, separator byte) ([]string, error) {
+	var parts []string
+
+	var err error
+
+	var quoted bool
+	var escaped bool
+	var brackets bool
+
+	start := 0
+
+	for index := 0; index < len(value); index++ {
+		char := value[index]
+
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && char == '\\':
+			escaped = true
+		case char == '"':
+			quoted = !quoted
+		case quoted:
+		case char == '<':
+			if brackets && err == nil {
+				err = ErrNestedBracket
+			}
+
+			brackets = true
+		case char == '>':
+			brackets = false
+		case char == separator && !brackets:
+			parts = append(parts, value[start:index])
+			start = index + 1
+		}
+	}
+
+	switch {
+	case err != nil:
+	case quoted:
+		err = ErrUnterminatedQuote
+	case brackets:
+		err = ErrUnterminatedBracket
+	}
+
+	return append(parts, value[start:]), err
+}
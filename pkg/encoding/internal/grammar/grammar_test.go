@@ -0,0 +1,41 @@
+package grammar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	table := []struct {
+		name  string
+		value string
+		want  []string
+		err   error
+	}{
+		{name: "plain list", value: "INVITE, ACK", want: []string{"INVITE", " ACK"}},
+		{name: "separator in a quoted string", value: `"Doe, John" <sip:j@a.com>, <sip:b@b.com>`, want: []string{`"Doe, John" <sip:j@a.com>`, " <sip:b@b.com>"}},
+		{name: "separator in angle brackets", value: "<sip:a@a.com;x=1,2>,<sip:b@b.com>", want: []string{"<sip:a@a.com;x=1,2>", "<sip:b@b.com>"}},
+		{name: "escaped quote", value: `"a\",b",c`, want: []string{`"a\",b"`, "c"}},
+		{name: "unterminated quoted string", value: `"a,b`, want: []string{`"a,b`}, err: ErrUnterminatedQuote},
+		{name: "unterminated angle bracket", value: "<sip:a@a.com,b", want: []string{"<sip:a@a.com,b"}, err: ErrUnterminatedBracket},
+		{name: "nested angle bracket", value: "<<sip:a@a.com>>,b", want: []string{"<<sip:a@a.com>>", "b"}, err: ErrNestedBracket},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Split(tc.value, ',')
+
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestIsToken(t *testing.T) {
+	assert.True(t, IsToken("z9hG4bK776asdhds"))
+	assert.True(t, IsToken("-.!%*_+`'~"))
+	assert.False(t, IsToken(""))
+	assert.False(t, IsToken("a b"))
+	assert.False(t, IsToken("a@b"))
+}
@@ -0,0 +1,213 @@
+package message
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/otoru/party/pkg/encoding/header"
+	"github.com/otoru/party/pkg/encoding/uri"
+)
+
+// DefaultMaxForwards is the Max-Forwards value recommended by RFC 3261 section 8.1.1.6 for new requests.
+const DefaultMaxForwards = 70
+
+// random returns size random octets encoded in hexadecimal.
+func random(size int) (string, error) {
+	octets := make([]byte, size)
+
+	if _, err := rand.Read(octets); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrOnGenerateSIPMessage, err)
+	}
+
+	return hex.EncodeToString(octets), nil
+}
+
+// NewBranch returns a new branch parameter, unique across space and time, starting with the magic cookie required
+// by RFC 3261 section 8.1.1.7.
+func NewBranch() (string, error) {
+	value, err := random(12)
+
+	if err != nil {
+		return "", err
+	}
+
+	return header.MagicCookie + value, nil
+}
+
+// NewTag returns a new random tag for the From or To header, as required by RFC 3261 section 19.3.
+func NewTag() (string, error) {
+	return random(8)
+}
+
+// NewCallID returns a new globally unique Call-ID. When host is not empty, it is appended after an "@", as
+// recommended by RFC 3261 section 8.1.1.4.
+func NewCallID(host string) (string, error) {
+	value, err := random(16)
+
+	if err != nil {
+		return "", err
+	}
+
+	if host == "" {
+		return value, nil
+	}
+
+	return value + "@" + host, nil
+}
+
+// NewRequest creates a new SIP request for method and target, completing headers with everything that can be
+// generated:
+//   - a Call-ID, if there is none
+//   - a CSeq with sequence 1, if there is none
+//   - a Max-Forwards of 70, if there is none
+//   - a tag on the From header, if it has none
+//   - a branch on the topmost Via header, if it has none
+//
+// The To, From and Via headers must be provided, since they depend on the user and transport. The headers are
+// copied, so the map of the caller is left untouched.
+func NewRequest(method Method, target *uri.URI, headers Headers, body []byte) (*Message, error) {
+	message := new(Message)
+
+	if err := message.SetRequestLine(&RequestLine{Method: method, URI: target}); err != nil {
+		return nil, err
+	}
+
+	headers = copyHeaders(headers)
+
+	if !headers.Has("Call-ID") {
+		id, err := NewCallID("")
+
+		if err != nil {
+			return nil, err
+		}
+
+		headers.SetCallID(id)
+	}
+
+	if !headers.Has("Max-Forwards") {
+		headers.SetMaxForwards(DefaultMaxForwards)
+	}
+
+	if headers.Has("From") {
+		if err := addTag(headers, "From", ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if headers.Has("Via") {
+		if err := addBranch(headers); err != nil {
+			return nil, err
+		}
+	}
+
+	request, err := CreateSIPRequest(message.Metadata, headers, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.Request = message.Request
+
+	return request, nil
+}
+
+// NewResponseFor creates a new SIP response to request, as defined in RFC 3261 section 8.2.6.
+//
+// The Via, From, To, Call-ID and CSeq headers are copied from the request, and tag is added to the To header when it
+// has none and the response is not a 100 Trying. All the responses of a UAS to a request must carry the same tag, so
+// callers generate it once with NewTag and pass it to each of them. When tag is empty, a new one is generated. When
+// reason is empty, the default reason phrase of the code is used.
+func NewResponseFor(request *Message, code StatusCode, reason string, tag string) (*Message, error) {
+	if request == nil || request.Kind != Request {
+		return nil, ErrInvalidSipMessage
+	}
+
+	message := new(Message)
+
+	if err := message.SetStatusLine(&StatusLine{Code: code, Reason: reason}); err != nil {
+		return nil, err
+	}
+
+	headers := make(Headers)
+
+	for _, name := range []string{"Via", "From", "To", "Call-ID", "CSeq"} {
+		for _, value := range request.Headers.Values(name) {
+			headers.Add(name, value)
+		}
+	}
+
+	if code != 100 && headers.Has("To") {
+		if err := addTag(headers, "To", tag); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := CreateSIPResponse(message.Metadata, headers, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response.Status = message.Status
+
+	return response, nil
+}
+
+// addTag appends tag to the header name when its value has none, keeping the rest of the value as is. A new tag is
+// generated when tag is empty.
+func addTag(headers Headers, name string, tag string) error {
+	addr, err := headers.NameAddr(name)
+
+	if err != nil {
+		return err
+	}
+
+	if addr.Tag() != "" {
+		return nil
+	}
+
+	if tag == "" {
+		if tag, err = NewTag(); err != nil {
+			return err
+		}
+	}
+
+	headers.Set(name, headers.Get(name)+";tag="+tag)
+
+	return nil
+}
+
+// copyHeaders returns a copy of headers, with copies of their values, or empty Headers when headers is nil.
+func copyHeaders(headers Headers) Headers {
+	copied := make(Headers, len(headers))
+
+	for key, values := range headers {
+		copied[key] = append([]string(nil), values...)
+	}
+
+	return copied
+}
+
+// addBranch adds a new branch to the topmost Via header when it has none.
+func addBranch(headers Headers) error {
+	via, err := headers.TopVia()
+
+	if err != nil {
+		return err
+	}
+
+	if via.Branch != "" {
+		return nil
+	}
+
+	if via.Branch, err = NewBranch(); err != nil {
+		return err
+	}
+
+	if _, err := headers.PopVia(); err != nil {
+		return err
+	}
+
+	return headers.PushVia(via)
+}
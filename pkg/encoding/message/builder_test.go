@@ -0,0 +1,214 @@
+package message
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/otoru/party/pkg/encoding/uri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentifierGenerators(t *testing.T) {
+	t.Run("Generates branches with the magic cookie", func(t *testing.T) {
+		first, err := NewBranch()
+
+		assert.Nil(t, err)
+
+		second, err := NewBranch()
+
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(first, "z9hG4bK"))
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("Generates unique tags", func(t *testing.T) {
+		first, err := NewTag()
+
+		assert.Nil(t, err)
+
+		second, err := NewTag()
+
+		assert.Nil(t, err)
+		assert.Len(t, first, 16)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("Generates Call-IDs with and without host", func(t *testing.T) {
+		id, err := NewCallID("")
+
+		assert.Nil(t, err)
+		assert.NotContains(t, id, "@")
+
+		id, err = NewCallID("atlanta.com")
+
+		assert.Nil(t, err)
+		assert.True(t, strings.HasSuffix(id, "@atlanta.com"))
+	})
+}
+
+func TestNewRequest(t *testing.T) {
+	target := &uri.URI{Scheme: "sip", User: "bob", Host: "biloxi.com"}
+
+	t.Run("Generates the missing headers", func(t *testing.T) {
+		headers := Headers{
+			"Via":  {"SIP/2.0/UDP pc33.atlanta.com"},
+			"To":   {"Bob <sip:bob@biloxi.com>"},
+			"From": {"Alice <sip:alice@atlanta.com>"},
+		}
+
+		result, err := NewRequest(MethodInvite, target, headers, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, Metadata{"method": "INVITE", "uri": "sip:bob@biloxi.com", "version": "SIP/2.0"}, result.Metadata)
+		assert.Equal(t, MethodInvite, result.Request.Method)
+		assert.Equal(t, "70", result.Headers.Get("Max-Forwards"))
+		assert.Equal(t, "1 INVITE", result.Headers.Get("CSeq"))
+		assert.Equal(t, "Bob <sip:bob@biloxi.com>", result.Headers.Get("To"))
+
+		id, err := result.Headers.CallID()
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, id)
+
+		from, err := result.Headers.From()
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, from.Tag())
+
+		via, err := result.Headers.TopVia()
+
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(via.Branch, "z9hG4bK"))
+
+		assert.Equal(t, Headers{
+			"Via":  {"SIP/2.0/UDP pc33.atlanta.com"},
+			"To":   {"Bob <sip:bob@biloxi.com>"},
+			"From": {"Alice <sip:alice@atlanta.com>"},
+		}, headers)
+	})
+
+	t.Run("Keeps the headers provided", func(t *testing.T) {
+		headers := Headers{
+			"Via":          {"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds"},
+			"To":           {"Bob <sip:bob@biloxi.com>"},
+			"From":         {"Alice <sip:alice@atlanta.com>;tag=1928301774"},
+			"Call-ID":      {"a84b4c76e66710@pc33.atlanta.com"},
+			"CSeq":         {"314159 INVITE"},
+			"Max-Forwards": {"10"},
+		}
+
+		result, err := NewRequest(MethodInvite, target, headers, []byte("v=0"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, Headers{
+			"Via":          {"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds"},
+			"To":           {"Bob <sip:bob@biloxi.com>"},
+			"From":         {"Alice <sip:alice@atlanta.com>;tag=1928301774"},
+			"Call-ID":      {"a84b4c76e66710@pc33.atlanta.com"},
+			"CSeq":         {"314159 INVITE"},
+			"Max-Forwards": {"10"},
+		}, result.Headers)
+		assert.Equal(t, []byte("v=0"), result.Body)
+	})
+
+	t.Run("Returns an error when a required header is missing", func(t *testing.T) {
+		result, err := NewRequest(MethodInvite, target, Headers{"To": {"sip:bob@biloxi.com"}}, nil)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrMissingRequiredHeader)
+	})
+
+	t.Run("Returns an error when the target is missing", func(t *testing.T) {
+		result, err := NewRequest(MethodInvite, nil, nil, nil)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrOnGenerateSIPMessage)
+	})
+}
+
+func TestNewResponseFor(t *testing.T) {
+	request := &Message{
+		Kind: Request,
+		Metadata: Metadata{
+			"method":  "INVITE",
+			"uri":     "sip:bob@biloxi.com",
+			"version": "SIP/2.0",
+		},
+		Headers: Headers{
+			"Via": {
+				"SIP/2.0/UDP server10.biloxi.com;branch=z9hG4bKnashds8",
+				"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds",
+			},
+			"Max-Forwards": {"70"},
+			"To":           {"Bob <sip:bob@biloxi.com>"},
+			"From":         {"Alice <sip:alice@atlanta.com>;tag=1928301774"},
+			"Call-ID":      {"a84b4c76e66710@pc33.atlanta.com"},
+			"CSeq":         {"314159 INVITE"},
+			"Contact":      {"<sip:alice@pc33.atlanta.com>"},
+		},
+	}
+
+	t.Run("Copies the headers and adds a To tag", func(t *testing.T) {
+		result, err := NewResponseFor(request, 180, "", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, Metadata{"version": "SIP/2.0", "code": "180", "reason": "Ringing"}, result.Metadata)
+		assert.Equal(t, &StatusLine{Version: SIPVersion, Code: 180, Reason: "Ringing"}, result.Status)
+		assert.Equal(t, request.Headers["Via"], result.Headers["Via"])
+		assert.Equal(t, request.Headers["From"], result.Headers["From"])
+		assert.Equal(t, request.Headers["Call-ID"], result.Headers["Call-ID"])
+		assert.Equal(t, request.Headers["CSeq"], result.Headers["CSeq"])
+		assert.False(t, result.Headers.Has("Contact"))
+		assert.False(t, result.Headers.Has("Max-Forwards"))
+		assert.True(t, strings.HasPrefix(result.Headers.Get("To"), "Bob <sip:bob@biloxi.com>;tag="))
+		assert.Equal(t, "Bob <sip:bob@biloxi.com>", request.Headers.Get("To"))
+	})
+
+	t.Run("Adds the same To tag to every response", func(t *testing.T) {
+		ringing, err := NewResponseFor(request, 180, "", "a6c85cf")
+
+		assert.Nil(t, err)
+
+		ok, err := NewResponseFor(request, 200, "", "a6c85cf")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Bob <sip:bob@biloxi.com>;tag=a6c85cf", ringing.Headers.Get("To"))
+		assert.Equal(t, ringing.Headers.Get("To"), ok.Headers.Get("To"))
+	})
+
+	t.Run("Does not add a To tag to 100 Trying", func(t *testing.T) {
+		result, err := NewResponseFor(request, 100, "", "a6c85cf")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Trying", result.Metadata["reason"])
+		assert.Equal(t, "Bob <sip:bob@biloxi.com>", result.Headers.Get("To"))
+	})
+
+	t.Run("Keeps an existing To tag and custom reason", func(t *testing.T) {
+		tagged := &Message{Kind: Request, Metadata: request.Metadata, Headers: Headers{}}
+
+		for name, values := range request.Headers {
+			tagged.Headers[name] = values
+		}
+
+		tagged.Headers.Set("To", "Bob <sip:bob@biloxi.com>;tag=a6c85cf")
+
+		result, err := NewResponseFor(tagged, 486, "Busy Here Now", "314159")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Busy Here Now", result.Metadata["reason"])
+		assert.Equal(t, "Bob <sip:bob@biloxi.com>;tag=a6c85cf", result.Headers.Get("To"))
+	})
+
+	t.Run("Returns an error for responses and invalid codes", func(t *testing.T) {
+		result, err := NewResponseFor(&Message{Kind: Response}, 200, "", "")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+
+		result, err = NewResponseFor(request, 99, "", "")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrOnGenerateSIPMessage)
+	})
+}
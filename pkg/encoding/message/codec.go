@@ -0,0 +1,116 @@
+package message
+
+import (
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BodyCodec encodes and decodes the bodies of a media type, such as application/sdp.
+type BodyCodec interface {
+	// Unmarshal parses payload, sent with the Content-Type header contentType, into the value pointed to by v.
+	Unmarshal(contentType string, payload []byte, v any) error
+
+	// Marshal returns the encoding of v along with the Content-Type header to send it with, which may add
+	// parameters, such as a multipart boundary, to the requested contentType.
+	Marshal(contentType string, v any) (string, []byte, error)
+}
+
+// codecs holds the registered body codecs, keyed by media type in lower case.
+var codecs = struct {
+	sync.RWMutex
+	byType map[string]BodyCodec
+}{byType: make(map[string]BodyCodec)}
+
+// RegisterBodyCodec makes codec available to DecodeBody and SetBody for bodies of mediaType, replacing any codec
+// registered for it before. A media type with a "*" subtype, such as "multipart/*", registers codec for every
+// subtype without a codec of its own.
+func RegisterBodyCodec(mediaType string, codec BodyCodec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+
+	codecs.byType[strings.ToLower(mediaType)] = codec
+}
+
+// LookupBodyCodec returns the codec registered for the media type of contentType, if there is one.
+func LookupBodyCodec(contentType string) (BodyCodec, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	if err != nil {
+		return nil, false
+	}
+
+	codecs.RLock()
+	defer codecs.RUnlock()
+
+	if codec, ok := codecs.byType[mediaType]; ok {
+		return codec, true
+	}
+
+	major, _, _ := strings.Cut(mediaType, "/")
+	codec, ok := codecs.byType[major+"/*"]
+
+	return codec, ok
+}
+
+// DecodeBody parses the body of the message into the value pointed to by v, using the codec registered for its
+// Content-Type header. A *[]byte or *string receives a copy of the body as is, whatever its Content-Type.
+func (message *Message) DecodeBody(v any) error {
+	switch target := v.(type) {
+	case *[]byte:
+		*target = append([]byte(nil), message.Body...)
+
+		return nil
+	case *string:
+		*target = string(message.Body)
+
+		return nil
+	}
+
+	contentType := message.Headers.Get("Content-Type")
+	codec, ok := LookupBodyCodec(contentType)
+
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnsupportedBody, contentType)
+	}
+
+	return codec.Unmarshal(contentType, message.Body, v)
+}
+
+// SetBody replaces the body of the message by the encoding of v, using the codec registered for contentType, and
+// updates the Content-Type and Content-Length headers to match it. A []byte or string is used as is, whatever
+// contentType is.
+func (message *Message) SetBody(contentType string, v any) error {
+	var payload []byte
+
+	switch value := v.(type) {
+	case []byte:
+		payload = value
+	case string:
+		payload = []byte(value)
+	default:
+		codec, ok := LookupBodyCodec(contentType)
+
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnsupportedBody, contentType)
+		}
+
+		var err error
+
+		if contentType, payload, err = codec.Marshal(contentType, v); err != nil {
+			return err
+		}
+	}
+
+	if len(payload) == 0 {
+		payload = nil
+	}
+
+	message.Body = payload
+	message.SetHeader("Content-Type", contentType)
+	message.SetHeader("Content-Length", strconv.Itoa(len(payload)))
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// upperCodec is a body codec that stores text in upper case, used to test the registry.
+type upperCodec struct{}
+
+func (upperCodec) Unmarshal(contentType string, payload []byte, v any) error {
+	target, ok := v.(*[]string)
+
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnsupportedBody, v)
+	}
+
+	*target = strings.Fields(strings.ToLower(string(payload)))
+
+	return nil
+}
+
+func (upperCodec) Marshal(contentType string, v any) (string, []byte, error) {
+	words, ok := v.([]string)
+
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %T", ErrUnsupportedBody, v)
+	}
+
+	return contentType + ";charset=us-ascii", []byte(strings.ToUpper(strings.Join(words, " "))), nil
+}
+
+func TestLookupBodyCodec(t *testing.T) {
+	RegisterBodyCodec("Application/X-Upper", upperCodec{})
+	RegisterBodyCodec("x-upper/*", upperCodec{})
+
+	table := []struct {
+		name        string
+		contentType string
+		want        bool
+	}{
+		{name: "exact media type", contentType: "application/x-upper", want: true},
+		{name: "media type in another case", contentType: "APPLICATION/X-UPPER", want: true},
+		{name: "media type with parameters", contentType: "application/x-upper; charset=utf-8", want: true},
+		{name: "wildcard subtype", contentType: "x-upper/anything", want: true},
+		{name: "unregistered media type", contentType: "application/x-lower", want: false},
+		{name: "invalid content type", contentType: "application/", want: false},
+		{name: "empty content type", contentType: "", want: false},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			codec, ok := LookupBodyCodec(tc.contentType)
+
+			assert.Equal(t, tc.want, ok)
+
+			if tc.want {
+				assert.Equal(t, upperCodec{}, codec)
+			}
+		})
+	}
+}
+
+func TestMessageBodyCodecs(t *testing.T) {
+	RegisterBodyCodec("application/x-upper", upperCodec{})
+
+	t.Run("encodes and decodes with the registered codec", func(t *testing.T) {
+		message := new(Message)
+
+		assert.Nil(t, message.SetBody("application/x-upper", []string{"hello", "world"}))
+
+		assert.Equal(t, []byte("HELLO WORLD"), message.Body)
+		assert.Equal(t, "application/x-upper;charset=us-ascii", message.Headers.Get("Content-Type"))
+		assert.Equal(t, "11", message.Headers.Get("Content-Length"))
+		assert.Equal(t, []string{"Content-Type", "Content-Length"}, message.Order)
+
+		var words []string
+
+		assert.Nil(t, message.DecodeBody(&words))
+		assert.Equal(t, []string{"hello", "world"}, words)
+	})
+
+	t.Run("uses raw values as they are", func(t *testing.T) {
+		message := new(Message)
+
+		assert.Nil(t, message.SetBody("application/sdp", "v=0\r\n"))
+
+		assert.Equal(t, []byte("v=0\r\n"), message.Body)
+		assert.Equal(t, "5", message.Headers.Get("Content-Length"))
+
+		var text string
+		var octets []byte
+
+		assert.Nil(t, message.DecodeBody(&text))
+		assert.Nil(t, message.DecodeBody(&octets))
+		assert.Equal(t, "v=0\r\n", text)
+		assert.Equal(t, []byte("v=0\r\n"), octets)
+
+		assert.Nil(t, message.SetBody("application/sdp", []byte{}))
+
+		assert.Nil(t, message.Body)
+		assert.Equal(t, "0", message.Headers.Get("Content-Length"))
+	})
+
+	t.Run("fails without a codec", func(t *testing.T) {
+		message := new(Message)
+
+		assert.ErrorIs(t, message.SetBody("application/x-lower", []string{"hello"}), ErrUnsupportedBody)
+
+		assert.Nil(t, message.Body)
+		assert.Nil(t, message.Headers)
+
+		message.SetHeader("Content-Type", "application/x-lower")
+		message.Body = []byte("hello")
+
+		var words []string
+
+		assert.ErrorIs(t, message.DecodeBody(&words), ErrUnsupportedBody)
+	})
+
+	t.Run("fails when the codec does", func(t *testing.T) {
+		message := new(Message)
+
+		assert.ErrorIs(t, message.SetBody("application/x-upper", 42), ErrUnsupportedBody)
+
+		message.SetHeader("Content-Type", "application/x-upper")
+
+		var number int
+
+		assert.ErrorIs(t, message.DecodeBody(&number), ErrUnsupportedBody)
+	})
+}
@@ -5,3 +5,6 @@ const Request = "REQUEST"
 
 // Response is a constant used to identify response kind messages.
 const Response = "RESPONSE"
+
+// SIPVersion is the protocol version defined in RFC 3261, used in request and status lines.
+const SIPVersion = "SIP/2.0"
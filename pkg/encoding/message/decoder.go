@@ -0,0 +1,160 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Decoder reads and decodes SIP messages from a stream, such as a TCP or TLS connection.
+//
+// Each message is framed using the Content-Length header, as required by RFC 3261 section 18.3 for stream-oriented
+// transports. Empty lines received between messages are treated as keep-alives and discarded.
+//
+// The limits given with WithLimits, or DefaultLimits, are enforced while reading, so a peer cannot make the decoder
+// buffer more than a message of the maximum size. The stream cannot be resynchronized after a limit is exceeded, so
+// the connection should be closed.
+type Decoder struct {
+	reader  *bufio.Reader
+	options []UnmarshalOption
+	limits  Limits
+}
+
+// NewDecoder returns a new decoder that reads from reader, passing options to Unmarshal for each message.
+func NewDecoder(reader io.Reader, options ...UnmarshalOption) *Decoder {
+	return &Decoder{reader: bufio.NewReader(reader), options: options, limits: newUnmarshalOptions(options).limits}
+}
+
+// Decode reads the next SIP message from the stream.
+//
+// It returns io.EOF when the stream ends cleanly between messages and io.ErrUnexpectedEOF when it ends in the
+// middle of one.
+func (decoder *Decoder) Decode() (*Message, error) {
+	var buffer bytes.Buffer
+
+	length := -1
+	headers := 0
+
+	for {
+		line, err := decoder.readLine(buffer.Len())
+
+		if err != nil {
+			if errors.Is(err, io.EOF) && buffer.Len() == 0 && len(bytes.TrimSpace(line)) == 0 {
+				return nil, io.EOF
+			}
+
+			if errors.Is(err, io.EOF) {
+				return nil, io.ErrUnexpectedEOF
+			}
+
+			return nil, err
+		}
+
+		if bytes.Equal(line, CRLF) || bytes.Equal(line, []byte("\n")) {
+			if buffer.Len() == 0 {
+				// Keep-alive between messages
+				continue
+			}
+
+			buffer.Write(line)
+
+			break
+		}
+
+		if buffer.Len() > 0 && line[0] != ' ' && line[0] != '\t' {
+			if headers++; exceeds(headers, decoder.limits.Headers) {
+				return nil, overLimit(ErrTooManyHeaders, "header count", headers, decoder.limits.Headers)
+			}
+		}
+
+		buffer.Write(line)
+
+		if value, ok := contentLength(line); ok {
+			size, err := strconv.Atoi(value)
+
+			if err != nil || size < 0 {
+				return nil, &ParseError{Header: "Content-Length", Reason: "invalid value", Err: ErrInvalidSipMessage}
+			}
+
+			length = size
+		}
+	}
+
+	// The body is only allocated once its declared length is known to be within the limits
+	if exceeds(length, decoder.limits.BodySize) {
+		return nil, overLimit(ErrBodyTooLarge, "body size", length, decoder.limits.BodySize)
+	}
+
+	if exceeds(buffer.Len()+length, decoder.limits.MessageSize) {
+		return nil, overLimit(ErrMessageTooLarge, "message size", buffer.Len()+length, decoder.limits.MessageSize)
+	}
+
+	if length < 0 {
+		return nil, &ParseError{Header: "Content-Length", Reason: "required on stream transports", Err: ErrInvalidSipMessage}
+	}
+
+	body := make([]byte, length)
+
+	if _, err := io.ReadFull(decoder.reader, body); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	buffer.Write(body)
+
+	message := new(Message)
+
+	if err := Unmarshal(buffer.Bytes(), message, decoder.options...); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// readLine reads the next line of the stream, which continues a message of size octets, failing as soon as the line
+// or the message exceeds its limit.
+func (decoder *Decoder) readLine(size int) ([]byte, error) {
+	var line []byte
+
+	for {
+		chunk, err := decoder.reader.ReadSlice('\n')
+		line = append(line, chunk...)
+
+		if length := len(bytes.TrimRight(line, "\r\n")); exceeds(length, decoder.limits.HeaderLineLength) {
+			return nil, overLimit(ErrHeaderLineTooLong, "line length", length, decoder.limits.HeaderLineLength)
+		}
+
+		if exceeds(size+len(line), decoder.limits.MessageSize) {
+			return nil, overLimit(ErrMessageTooLarge, "message size", size+len(line), decoder.limits.MessageSize)
+		}
+
+		if err != bufio.ErrBufferFull {
+			return line, err
+		}
+	}
+}
+
+// contentLength returns the value of line if it is a Content-Length header, in its long or compact form.
+func contentLength(line []byte) (string, bool) {
+	if line[0] == ' ' || line[0] == '\t' {
+		return "", false
+	}
+
+	fields := strings.SplitN(string(line), ":", 2)
+
+	if len(fields) != 2 {
+		return "", false
+	}
+
+	if CanonicalHeaderKey(fields[0]) != "Content-Length" {
+		return "", false
+	}
+
+	return strings.TrimSpace(fields[1]), true
+}
@@ -0,0 +1,121 @@
+package message
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var decoderInvite = "INVITE sip:bob@biloxi.com SIP/2.0\r\n" +
+	"Via: SIP/2.0/TCP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+	"Max-Forwards: 70\r\n" +
+	"To: Bob <sip:bob@biloxi.com>\r\n" +
+	"From: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+	"Call-ID: a84b4c76e66710\r\n" +
+	"CSeq: 314159 INVITE\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"Content-Length: 11\r\n" +
+	"\r\n" +
+	"Hello World"
+
+var decoderResponse = "SIP/2.0 200 OK\r\n" +
+	"Via: SIP/2.0/TCP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+	"To: Bob <sip:bob@biloxi.com>;tag=a6c85cf\r\n" +
+	"From: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+	"Call-ID: a84b4c76e66710\r\n" +
+	"CSeq: 314159 INVITE\r\n" +
+	"l: 0\r\n" +
+	"\r\n"
+
+func TestDecoderWithValidStreams(t *testing.T) {
+	table := []struct {
+		input  io.Reader
+		kinds  []string
+		bodies [][]byte
+	}{
+		{
+			input:  bytes.NewBufferString(decoderInvite + decoderResponse),
+			kinds:  []string{Request, Response},
+			bodies: [][]byte{[]byte("Hello World"), nil},
+		},
+		{
+			input:  bytes.NewBufferString("\r\n\r\n" + decoderResponse + "\r\n" + decoderInvite),
+			kinds:  []string{Response, Request},
+			bodies: [][]byte{nil, []byte("Hello World")},
+		},
+		{
+			input:  iotest.OneByteReader(bytes.NewBufferString(decoderInvite + decoderInvite)),
+			kinds:  []string{Request, Request},
+			bodies: [][]byte{[]byte("Hello World"), []byte("Hello World")},
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			decoder := NewDecoder(test.input)
+
+			for position, kind := range test.kinds {
+				got, err := decoder.Decode()
+
+				assert.Nil(t, err)
+
+				assert.Equal(t, kind, got.Kind)
+
+				assert.Equal(t, test.bodies[position], got.Body)
+			}
+
+			got, err := decoder.Decode()
+
+			assert.Nil(t, got)
+
+			assert.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+func TestDecoderWithInvalidStreams(t *testing.T) {
+	table := []struct {
+		input string
+		err   error
+	}{
+		{
+			input: "OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Via: SIP/2.0/TCP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+				"Call-ID: a84b4c76e66710\r\n" +
+				"\r\n",
+			err: ErrInvalidSipMessage,
+		},
+		{
+			input: "OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Call-ID: a84b4c76e66710\r\n" +
+				"Content-Length: -1\r\n" +
+				"\r\n",
+			err: ErrInvalidSipMessage,
+		},
+		{
+			input: decoderInvite[:len(decoderInvite)-5],
+			err:   io.ErrUnexpectedEOF,
+		},
+		{
+			input: "OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Call-ID: a84b4c76e66710\r\n",
+			err: io.ErrUnexpectedEOF,
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			decoder := NewDecoder(bytes.NewBufferString(test.input))
+
+			got, err := decoder.Decode()
+
+			assert.Nil(t, got)
+
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
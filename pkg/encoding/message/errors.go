@@ -1,11 +1,14 @@
 package message
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ErrInvalidSipMessage when we have an invalid SIP message.
 var ErrInvalidSipMessage = fmt.Errorf("invalid SIP message")
 
-// ErrInvalidBodyOnSIPMessage occurs when we have an error when decoding the body of a message.
+// ErrInvalidBodyOnSIPMessage occurs when the body of a message is shorter than its Content-Length header declares.
 var ErrInvalidBodyOnSIPMessage = fmt.Errorf("invalid body on SIP message")
 
 // ErrMissingRequiredMetadataField occurs when a required field is missing from the metadata of a message.
@@ -16,3 +19,55 @@ var ErrMissingRequiredHeader = fmt.Errorf("missing required header on SIP messag
 
 // ErrOnGenerateSIPMessage occurs when we have an unexpected error when trying to generate a SIP message.
 var ErrOnGenerateSIPMessage = fmt.Errorf("failed to generate a SIP message")
+
+// ErrNonCompliantSipMessage classifies the deviations from RFC 3261 recorded as warnings by lenient parsing.
+var ErrNonCompliantSipMessage = fmt.Errorf("non-compliant SIP message")
+
+// ErrUnsupportedBody occurs when no codec is registered for the Content-Type of a message body.
+var ErrUnsupportedBody = fmt.Errorf("unsupported body on SIP message")
+
+// ParseError describes why a SIP message could not be parsed and where the problem was found.
+//
+// It wraps one of the sentinel errors of this package, so errors.Is keeps working on it.
+type ParseError struct {
+	// Line is the number of the offending line, starting at 1 for the start line.
+	Line int
+
+	// Offset is the position, in bytes from the start of the payload, of the offending line.
+	Offset int
+
+	// Header is the canonical name of the offending header, or empty if the problem is not in a header.
+	Header string
+
+	// Reason describes the problem.
+	Reason string
+
+	// Err is the sentinel error that classifies the problem, such as ErrInvalidSipMessage.
+	Err error
+}
+
+// Error returns the description of the problem, prefixed by its location.
+func (err *ParseError) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString(err.Err.Error())
+
+	if err.Line > 0 {
+		fmt.Fprintf(&builder, ": line %d (offset %d)", err.Line, err.Offset)
+	}
+
+	if err.Header != "" {
+		fmt.Fprintf(&builder, ": %s", err.Header)
+	}
+
+	if err.Reason != "" {
+		fmt.Fprintf(&builder, ": %s", err.Reason)
+	}
+
+	return builder.String()
+}
+
+// Unwrap returns the sentinel error that classifies the problem.
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,152 @@
+package message
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxSequence is the exclusive upper bound of CSeq sequence numbers, as defined in RFC 3261 section 8.1.1.5.
+const maxSequence = 1 << 31
+
+// CSeq is the typed value of the CSeq header, which orders transactions within a dialog.
+type CSeq struct {
+	// Sequence is the sequence number, which must be lower than 2**31.
+	Sequence uint32
+
+	// Method is the method of the request, which must match the method of the request line.
+	Method Method
+}
+
+// CSeq returns the parsed value of the CSeq header.
+func (headers Headers) CSeq() (*CSeq, error) {
+	if !headers.Has("CSeq") {
+		return nil, ErrMissingRequiredHeader
+	}
+
+	value := headers.Get("CSeq")
+	fields := strings.Fields(value)
+
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("%w: invalid CSeq %q", ErrInvalidSipMessage, value)
+	}
+
+	sequence, err := strconv.ParseUint(fields[0], 10, 32)
+
+	if err != nil || sequence >= maxSequence {
+		return nil, fmt.Errorf("%w: invalid CSeq %q", ErrInvalidSipMessage, value)
+	}
+
+	return &CSeq{Sequence: uint32(sequence), Method: Method(fields[1])}, nil
+}
+
+// SetCSeq replaces the value of the CSeq header.
+func (headers Headers) SetCSeq(sequence uint32, method Method) {
+	headers.Set("CSeq", strconv.FormatUint(uint64(sequence), 10)+" "+string(method))
+}
+
+// MaxForwards returns the value of the Max-Forwards header, which is between 0 and 255.
+func (headers Headers) MaxForwards() (int, error) {
+	value, err := headers.integer("Max-Forwards", 8)
+
+	return int(value), err
+}
+
+// SetMaxForwards replaces the value of the Max-Forwards header.
+func (headers Headers) SetMaxForwards(hops int) {
+	headers.Set("Max-Forwards", strconv.Itoa(hops))
+}
+
+// Expires returns the value of the Expires header, in seconds.
+func (headers Headers) Expires() (uint32, error) {
+	return headers.integer("Expires", 32)
+}
+
+// SetExpires replaces the value of the Expires header, in seconds.
+func (headers Headers) SetExpires(seconds uint32) {
+	headers.Set("Expires", strconv.FormatUint(uint64(seconds), 10))
+}
+
+// MinExpires returns the value of the Min-Expires header, in seconds.
+func (headers Headers) MinExpires() (uint32, error) {
+	return headers.integer("Min-Expires", 32)
+}
+
+// SetMinExpires replaces the value of the Min-Expires header, in seconds.
+func (headers Headers) SetMinExpires(seconds uint32) {
+	headers.Set("Min-Expires", strconv.FormatUint(uint64(seconds), 10))
+}
+
+// ContentLength returns the value of the Content-Length header, in octets.
+func (headers Headers) ContentLength() (int, error) {
+	value, err := headers.integer("Content-Length", 31)
+
+	return int(value), err
+}
+
+// SetContentLength replaces the value of the Content-Length header, in octets.
+func (headers Headers) SetContentLength(length int) {
+	headers.Set("Content-Length", strconv.Itoa(length))
+}
+
+// RetryAfter returns the delay of the Retry-After header, in seconds, ignoring its comment and parameters.
+func (headers Headers) RetryAfter() (uint32, error) {
+	if !headers.Has("Retry-After") {
+		return 0, ErrMissingRequiredHeader
+	}
+
+	value := headers.Get("Retry-After")
+
+	if index := strings.IndexAny(value, "(;"); index >= 0 {
+		value = value[:index]
+	}
+
+	seconds, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid Retry-After %q", ErrInvalidSipMessage, headers.Get("Retry-After"))
+	}
+
+	return uint32(seconds), nil
+}
+
+// SetRetryAfter replaces the value of the Retry-After header, in seconds.
+func (headers Headers) SetRetryAfter(seconds uint32) {
+	headers.Set("Retry-After", strconv.FormatUint(uint64(seconds), 10))
+}
+
+// CallID returns the value of the Call-ID header.
+func (headers Headers) CallID() (string, error) {
+	if !headers.Has("Call-ID") {
+		return "", ErrMissingRequiredHeader
+	}
+
+	value := headers.Get("Call-ID")
+
+	if value == "" || strings.ContainsAny(value, " \t") {
+		return "", fmt.Errorf("%w: invalid Call-ID %q", ErrInvalidSipMessage, value)
+	}
+
+	return value, nil
+}
+
+// SetCallID replaces the value of the Call-ID header.
+func (headers Headers) SetCallID(id string) {
+	headers.Set("Call-ID", id)
+}
+
+// integer returns the value of the header name as an unsigned integer that fits in size bits.
+func (headers Headers) integer(name string, size int) (uint32, error) {
+	if !headers.Has(name) {
+		return 0, ErrMissingRequiredHeader
+	}
+
+	value := headers.Get(name)
+	number, err := strconv.ParseUint(strings.TrimSpace(value), 10, size)
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid %s %q", ErrInvalidSipMessage, CanonicalHeaderKey(name), value)
+	}
+
+	return uint32(number), nil
+}
@@ -0,0 +1,133 @@
+package message
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadersCSeq(t *testing.T) {
+	t.Run("Returns the sequence and the method", func(t *testing.T) {
+		headers := Headers{"CSeq": {"314159 INVITE"}}
+
+		cseq, err := headers.CSeq()
+
+		assert.Nil(t, err)
+		assert.Equal(t, &CSeq{Sequence: 314159, Method: MethodInvite}, cseq)
+	})
+
+	t.Run("Returns an error if the value is invalid", func(t *testing.T) {
+		for index, value := range []string{"INVITE", "1 2 INVITE", "-1 INVITE", "2147483648 INVITE", "one INVITE"} {
+			t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+				cseq, err := Headers{"CSeq": {value}}.CSeq()
+
+				assert.Nil(t, cseq)
+				assert.ErrorIs(t, err, ErrInvalidSipMessage)
+			})
+		}
+	})
+
+	t.Run("Returns an error if the header is missing", func(t *testing.T) {
+		cseq, err := Headers{}.CSeq()
+
+		assert.Nil(t, cseq)
+		assert.ErrorIs(t, err, ErrMissingRequiredHeader)
+	})
+
+	t.Run("Sets the sequence and the method", func(t *testing.T) {
+		headers := Headers{"cseq": {"1 INVITE"}}
+
+		headers.SetCSeq(2, MethodBye)
+
+		assert.Equal(t, Headers{"CSeq": {"2 BYE"}}, headers)
+	})
+}
+
+func TestHeadersIntegerAccessors(t *testing.T) {
+	headers := Headers{
+		"Max-Forwards": {"70"},
+		"Expires":      {"3600"},
+		"Min-Expires":  {"60"},
+		"l":            {"142"},
+		"Retry-After":  {"18000 (in a meeting);duration=3600"},
+	}
+
+	t.Run("Returns the parsed values", func(t *testing.T) {
+		hops, err := headers.MaxForwards()
+		assert.Nil(t, err)
+		assert.Equal(t, 70, hops)
+
+		expires, err := headers.Expires()
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(3600), expires)
+
+		minimum, err := headers.MinExpires()
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(60), minimum)
+
+		length, err := headers.ContentLength()
+		assert.Nil(t, err)
+		assert.Equal(t, 142, length)
+
+		delay, err := headers.RetryAfter()
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(18000), delay)
+	})
+
+	t.Run("Returns an error if a value is invalid", func(t *testing.T) {
+		_, err := Headers{"Max-Forwards": {"256"}}.MaxForwards()
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+
+		_, err = Headers{"Expires": {"10000000000000000000"}}.Expires()
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+
+		_, err = Headers{"Content-Length": {"-1"}}.ContentLength()
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+
+		_, err = Headers{"Retry-After": {"soon"}}.RetryAfter()
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+	})
+
+	t.Run("Returns an error if a header is missing", func(t *testing.T) {
+		_, err := Headers{}.MaxForwards()
+		assert.ErrorIs(t, err, ErrMissingRequiredHeader)
+
+		_, err = Headers{}.RetryAfter()
+		assert.ErrorIs(t, err, ErrMissingRequiredHeader)
+	})
+
+	t.Run("Sets the values", func(t *testing.T) {
+		headers := Headers{}
+
+		headers.SetMaxForwards(69)
+		headers.SetExpires(7200)
+		headers.SetMinExpires(1800)
+		headers.SetContentLength(0)
+		headers.SetRetryAfter(120)
+
+		assert.Equal(t, Headers{
+			"Max-Forwards":   {"69"},
+			"Expires":        {"7200"},
+			"Min-Expires":    {"1800"},
+			"Content-Length": {"0"},
+			"Retry-After":    {"120"},
+		}, headers)
+	})
+}
+
+func TestHeadersCallID(t *testing.T) {
+	id, err := Headers{"i": {"a84b4c76e66710@pc33.atlanta.com"}}.CallID()
+	assert.Nil(t, err)
+	assert.Equal(t, "a84b4c76e66710@pc33.atlanta.com", id)
+
+	_, err = Headers{"Call-ID": {"a84b4c76 e66710"}}.CallID()
+	assert.ErrorIs(t, err, ErrInvalidSipMessage)
+
+	_, err = Headers{}.CallID()
+	assert.ErrorIs(t, err, ErrMissingRequiredHeader)
+
+	headers := Headers{}
+	headers.SetCallID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6@foo.bar.com")
+	assert.Equal(t, Headers{"Call-ID": {"f81d4fae-7dec-11d0-a765-00a0c91e6bf6@foo.bar.com"}}, headers)
+}
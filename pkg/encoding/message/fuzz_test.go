@@ -0,0 +1,61 @@
+package message
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func FuzzUnmarshal(f *testing.F) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*", "*", "*.dat"))
+
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	for _, path := range paths {
+		payload, err := os.ReadFile(path)
+
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(payload)
+	}
+
+	f.Add([]byte(decoderInvite))
+	f.Add([]byte(decoderResponse))
+	f.Add([]byte(""))
+	f.Add([]byte("\r\n\r\n"))
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		message := new(Message)
+
+		if err := Unmarshal(payload, message); err != nil {
+			return
+		}
+
+		encoded, err := Marshal(message)
+
+		if err != nil {
+			t.Fatalf("failed to marshal an unmarshaled message: %v", err)
+		}
+
+		again := new(Message)
+
+		if err := Unmarshal(encoded, again); err != nil {
+			t.Fatalf("failed to unmarshal a marshaled message: %v\n%q", err, encoded)
+		}
+
+		reencoded, err := Marshal(again)
+
+		if err != nil {
+			t.Fatalf("failed to marshal a message for the second time: %v", err)
+		}
+
+		if !bytes.Equal(encoded, reencoded) {
+			t.Fatalf("marshal did not reach a fixed point:\n%q\n%q", encoded, reencoded)
+		}
+	})
+}
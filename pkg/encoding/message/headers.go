@@ -0,0 +1,256 @@
+package message
+
+import (
+	"strings"
+)
+
+// compactForms maps the compact forms of header names defined in RFC 3261 section 7.3.3, and in the extensions
+// that register one, to their long forms.
+var compactForms = map[string]string{
+	"a": "Accept-Contact",
+	"b": "Referred-By",
+	"c": "Content-Type",
+	"d": "Request-Disposition",
+	"e": "Content-Encoding",
+	"f": "From",
+	"i": "Call-ID",
+	"j": "Reject-Contact",
+	"k": "Supported",
+	"l": "Content-Length",
+	"m": "Contact",
+	"o": "Event",
+	"r": "Refer-To",
+	"s": "Subject",
+	"t": "To",
+	"u": "Allow-Events",
+	"v": "Via",
+	"x": "Session-Expires",
+}
+
+// longForms maps the long form of header names to their compact forms.
+var longForms = func() map[string]string {
+	forms := make(map[string]string, len(compactForms))
+
+	for compact, long := range compactForms {
+		forms[long] = compact
+	}
+
+	return forms
+}()
+
+// headerNames holds the canonical spelling of the header names defined in RFC 3261 and in the extensions commonly
+// found in SIP messages.
+var headerNames = []string{
+	"Accept",
+	"Accept-Contact",
+	"Accept-Encoding",
+	"Accept-Language",
+	"Accept-Resource-Priority",
+	"Alert-Info",
+	"Allow",
+	"Allow-Events",
+	"Authentication-Info",
+	"Authorization",
+	"Call-ID",
+	"Call-Info",
+	"Contact",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-ID",
+	"Content-Language",
+	"Content-Length",
+	"Content-Transfer-Encoding",
+	"Content-Type",
+	"CSeq",
+	"Date",
+	"Error-Info",
+	"Event",
+	"Expires",
+	"From",
+	"Geolocation",
+	"History-Info",
+	"Identity",
+	"Identity-Info",
+	"In-Reply-To",
+	"Join",
+	"Max-Forwards",
+	"MIME-Version",
+	"Min-Expires",
+	"Min-SE",
+	"Organization",
+	"P-Access-Network-Info",
+	"P-Asserted-Identity",
+	"P-Charging-Vector",
+	"P-Preferred-Identity",
+	"Path",
+	"Priority",
+	"Privacy",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Require",
+	"RAck",
+	"Reason",
+	"Record-Route",
+	"Refer-To",
+	"Referred-By",
+	"Reject-Contact",
+	"Replaces",
+	"Reply-To",
+	"Request-Disposition",
+	"Require",
+	"Resource-Priority",
+	"Retry-After",
+	"Route",
+	"RSeq",
+	"Security-Client",
+	"Security-Server",
+	"Security-Verify",
+	"Server",
+	"Service-Route",
+	"Session-Expires",
+	"Session-ID",
+	"SIP-ETag",
+	"SIP-If-Match",
+	"Subject",
+	"Subscription-State",
+	"Supported",
+	"Target-Dialog",
+	"Timestamp",
+	"To",
+	"Unsupported",
+	"User-Agent",
+	"Via",
+	"Warning",
+	"WWW-Authenticate",
+}
+
+// knownNames maps the names of headerNames, both in lower case and in their canonical spelling, to their canonical
+// spelling.
+var knownNames = func() map[string]string {
+	names := make(map[string]string, 2*len(headerNames))
+
+	for _, name := range headerNames {
+		names[name] = name
+		names[strings.ToLower(name)] = name
+	}
+
+	return names
+}()
+
+// CanonicalHeaderKey returns the canonical format of the header name.
+//
+// Header names are case-insensitive, so "call-id", "CALL-ID" and the compact form "i" are all returned as "Call-ID".
+// Names that are not in headerNames, such as extension headers, are returned as received, so that they round-trip.
+func CanonicalHeaderKey(name string) string {
+	name = strings.TrimSpace(name)
+
+	if canonical, ok := knownNames[name]; ok {
+		return canonical
+	}
+
+	// Lower the name in a buffer, since the lookups below do not allocate and no known name is longer than it
+	var buffer [32]byte
+
+	if len(name) > len(buffer) {
+		return name
+	}
+
+	for index := 0; index < len(name); index++ {
+		char := name[index]
+
+		if 'A' <= char && char <= 'Z' {
+			char += 'a' - 'A'
+		}
+
+		buffer[index] = char
+	}
+
+	lower := buffer[:len(name)]
+
+	if long, ok := compactForms[string(lower)]; ok {
+		return long
+	}
+
+	if canonical, ok := knownNames[string(lower)]; ok {
+		return canonical
+	}
+
+	return name
+}
+
+// CompactHeaderKey returns the compact form of the header name, or its canonical format if it has no compact form.
+func CompactHeaderKey(name string) string {
+	canonical := CanonicalHeaderKey(name)
+
+	if compact, ok := longForms[canonical]; ok {
+		return compact
+	}
+
+	return canonical
+}
+
+// key returns the key under which name is stored, regardless of its case or form.
+func (headers Headers) key(name string) (string, bool) {
+	canonical := CanonicalHeaderKey(name)
+
+	if _, ok := headers[canonical]; ok {
+		return canonical, true
+	}
+
+	for key := range headers {
+		if strings.EqualFold(CanonicalHeaderKey(key), canonical) {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// Get returns the first value associated with the header name, or an empty string if there is none.
+func (headers Headers) Get(name string) string {
+	if values := headers.Values(name); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// Values returns all values associated with the header name.
+func (headers Headers) Values(name string) []string {
+	if key, ok := headers.key(name); ok {
+		return headers[key]
+	}
+
+	return nil
+}
+
+// Has reports whether the header name is present.
+func (headers Headers) Has(name string) bool {
+	_, ok := headers.key(name)
+
+	return ok
+}
+
+// Set replaces the values associated with the header name by value.
+func (headers Headers) Set(name string, value string) {
+	headers.Del(name)
+	headers[CanonicalHeaderKey(name)] = []string{value}
+}
+
+// Add appends value to the values associated with the header name.
+func (headers Headers) Add(name string, value string) {
+	key, ok := headers.key(name)
+
+	if !ok {
+		key = CanonicalHeaderKey(name)
+	}
+
+	headers[key] = append(headers[key], value)
+}
+
+// Del removes the values associated with the header name.
+func (headers Headers) Del(name string) {
+	for key, ok := headers.key(name); ok; key, ok = headers.key(name) {
+		delete(headers, key)
+	}
+}
@@ -0,0 +1,134 @@
+package message
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalHeaderKey(t *testing.T) {
+	table := []struct {
+		input string
+		want  string
+	}{
+		{input: "call-id", want: "Call-ID"},
+		{input: "CALL-ID", want: "Call-ID"},
+		{input: "i", want: "Call-ID"},
+		{input: "I", want: "Call-ID"},
+		{input: "cseq", want: "CSeq"},
+		{input: "v", want: "Via"},
+		{input: "l", want: "Content-Length"},
+		{input: "www-authenticate", want: "WWW-Authenticate"},
+		{input: "max-forwards", want: "Max-Forwards"},
+		{input: " record-ROUTE ", want: "Record-Route"},
+		{input: "X-Custom-Header", want: "X-Custom-Header"},
+		{input: "Cseq", want: "CSeq"},
+		{input: "Www-Authenticate", want: "WWW-Authenticate"},
+		{input: "V", want: "Via"},
+		{input: "min-se", want: "Min-SE"},
+		{input: "NewFangledHeader", want: "NewFangledHeader"},
+		{input: "x-custom-header", want: "x-custom-header"},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			assert.Equal(t, test.want, CanonicalHeaderKey(test.input))
+		})
+	}
+}
+
+func TestCanonicalHeaderKeyDoesNotAllocate(t *testing.T) {
+	for _, name := range []string{"Via", "Call-ID", "CSeq", "Max-Forwards", "X-Custom-Header", "call-id", "VIA"} {
+		t.Run(name, func(t *testing.T) {
+			allocations := testing.AllocsPerRun(100, func() {
+				CanonicalHeaderKey(name)
+			})
+
+			assert.Zero(t, allocations)
+		})
+	}
+}
+
+func TestCompactHeaderKey(t *testing.T) {
+	table := []struct {
+		input string
+		want  string
+	}{
+		{input: "Call-ID", want: "i"},
+		{input: "via", want: "v"},
+		{input: "Content-Length", want: "l"},
+		{input: "m", want: "m"},
+		{input: "CSeq", want: "CSeq"},
+		{input: "max-forwards", want: "Max-Forwards"},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			assert.Equal(t, test.want, CompactHeaderKey(test.input))
+		})
+	}
+}
+
+func TestHeadersAccessors(t *testing.T) {
+	t.Run("Get and Values ignore the case and form of names", func(t *testing.T) {
+		headers := Headers{
+			"call-id": {"a84b4c76e66710"},
+			"v":       {"SIP/2.0/UDP pc33.atlanta.com", "SIP/2.0/UDP bigbox3.site3.atlanta.com"},
+		}
+
+		assert.Equal(t, "a84b4c76e66710", headers.Get("Call-ID"))
+		assert.Equal(t, "a84b4c76e66710", headers.Get("i"))
+		assert.Equal(t, []string{"SIP/2.0/UDP pc33.atlanta.com", "SIP/2.0/UDP bigbox3.site3.atlanta.com"}, headers.Values("VIA"))
+		assert.True(t, headers.Has("Via"))
+		assert.False(t, headers.Has("Route"))
+		assert.Equal(t, "", headers.Get("Route"))
+		assert.Nil(t, headers.Values("Route"))
+	})
+
+	t.Run("Get and Add ignore the case of unknown names", func(t *testing.T) {
+		headers := Headers{"NewFangledHeader": {"first"}}
+
+		headers.Add("newfangledheader", "second")
+
+		assert.Equal(t, Headers{"NewFangledHeader": {"first", "second"}}, headers)
+		assert.Equal(t, "first", headers.Get("NEWFANGLEDHEADER"))
+	})
+
+	t.Run("Set replaces every form of the name", func(t *testing.T) {
+		headers := Headers{
+			"l":              {"10"},
+			"content-length": {"20"},
+		}
+
+		headers.Set("Content-Length", "0")
+
+		assert.Equal(t, Headers{"Content-Length": {"0"}}, headers)
+	})
+
+	t.Run("Add appends to the existing values", func(t *testing.T) {
+		headers := Headers{
+			"route": {"<sip:proxy.atlanta.com;lr>"},
+		}
+
+		headers.Add("Route", "<sip:proxy2.atlanta.com;lr>")
+		headers.Add("Supported", "timer")
+
+		assert.Equal(t, Headers{
+			"route":     {"<sip:proxy.atlanta.com;lr>", "<sip:proxy2.atlanta.com;lr>"},
+			"Supported": {"timer"},
+		}, headers)
+	})
+
+	t.Run("Del removes every form of the name", func(t *testing.T) {
+		headers := Headers{
+			"t":   {"<sip:bob@biloxi.com>"},
+			"TO":  {"<sip:bob@biloxi.com>"},
+			"Via": {"SIP/2.0/UDP pc33.atlanta.com"},
+		}
+
+		headers.Del("To")
+
+		assert.Equal(t, Headers{"Via": {"SIP/2.0/UDP pc33.atlanta.com"}}, headers)
+	})
+}
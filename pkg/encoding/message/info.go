@@ -0,0 +1,41 @@
+package message
+
+import (
+	"fmt"
+
+	"github.com/otoru/party/pkg/encoding/header"
+)
+
+// Infos returns the parsed values of every header name holding a URI in angle brackets followed by parameters,
+// such as Alert-Info, Call-Info or Error-Info.
+func (headers Headers) Infos(name string) ([]*header.Info, error) {
+	values := headers.Values(name)
+	result := make([]*header.Info, 0, len(values))
+
+	for _, value := range values {
+		info := new(header.Info)
+
+		if err := header.UnmarshalInfo(value, info); err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", ErrInvalidSipMessage, CanonicalHeaderKey(name), err)
+		}
+
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+// AlertInfo returns the parsed values of the Alert-Info header.
+func (headers Headers) AlertInfo() ([]*header.Info, error) {
+	return headers.Infos("Alert-Info")
+}
+
+// CallInfo returns the parsed values of the Call-Info header.
+func (headers Headers) CallInfo() ([]*header.Info, error) {
+	return headers.Infos("Call-Info")
+}
+
+// ErrorInfo returns the parsed values of the Error-Info header.
+func (headers Headers) ErrorInfo() ([]*header.Info, error) {
+	return headers.Infos("Error-Info")
+}
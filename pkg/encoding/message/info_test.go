@@ -0,0 +1,52 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/otoru/party/pkg/encoding/uri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadersInfo(t *testing.T) {
+	headers := Headers{
+		"Call-Info":  {"<http://wwww.example.com/alice/photo.jpg> ;purpose=icon", "<http://www.example.com/alice/> ;purpose=info"},
+		"Alert-Info": {"<urn:alert:service:normal>"},
+		"Error-Info": {"<sip:not-in-service-recording@atlanta.com>"},
+	}
+
+	t.Run("Returns every parsed Call-Info", func(t *testing.T) {
+		infos, err := headers.CallInfo()
+
+		assert.Nil(t, err)
+		assert.Len(t, infos, 2)
+		assert.Equal(t, "icon", infos[0].Purpose())
+		assert.Equal(t, "http", infos[0].URI.SchemeName())
+		assert.Equal(t, "http://www.example.com/alice/", infos[1].URI.String())
+	})
+
+	t.Run("Returns typed URIs of each scheme", func(t *testing.T) {
+		alerts, err := headers.AlertInfo()
+
+		assert.Nil(t, err)
+		assert.Equal(t, &uri.URN{NID: "alert", NSS: "service:normal"}, alerts[0].URI)
+
+		errorInfos, err := headers.ErrorInfo()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "atlanta.com", errorInfos[0].URI.(*uri.URI).Host)
+	})
+
+	t.Run("Returns no values for a missing header", func(t *testing.T) {
+		infos, err := Headers{}.CallInfo()
+
+		assert.Nil(t, err)
+		assert.Empty(t, infos)
+	})
+
+	t.Run("Returns an error for an invalid value", func(t *testing.T) {
+		infos, err := Headers{"Alert-Info": {"<bellcore-dr2>"}}.AlertInfo()
+
+		assert.Nil(t, infos)
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+	})
+}
@@ -0,0 +1,119 @@
+package message
+
+import (
+	"bytes"
+	"strings"
+)
+
+// repair rewrites the deviations tolerated by WithLenientParsing into their RFC 3261 form, returning the repaired
+// payload along with a warning, located in the original payload, for each kind of deviation found. A sipfrag needs
+// no blank line, so its absence is only repaired silently.
+func repair(payload []byte, sipfrag bool) ([]byte, []*ParseError) {
+	var buffer bytes.Buffer
+
+	var warnings []*ParseError
+
+	var bareLF bool
+
+	number := 0
+	offset := 0
+
+	for offset < len(payload) {
+		number++
+
+		line := payload[offset:]
+		next := len(payload)
+
+		if end := bytes.IndexByte(line, '\n'); end >= 0 {
+			line = line[:end]
+			next = offset + end + 1
+
+			if bytes.HasSuffix(line, []byte("\r")) {
+				line = line[:len(line)-1]
+			} else if !bareLF {
+				bareLF = true
+				warnings = append(warnings, warn(number, offset, "line ends with a bare LF"))
+			}
+		}
+
+		if number == 1 {
+			var reasons []string
+
+			if line, reasons = repairStartLine(line); len(reasons) > 0 {
+				for _, reason := range reasons {
+					warnings = append(warnings, warn(number, offset, reason))
+				}
+			}
+		}
+
+		if len(line) == 0 && number > 1 {
+			// We found the blank line, and the body is kept as is
+			buffer.Write(CRLF)
+			buffer.Write(payload[next:])
+
+			return buffer.Bytes(), warnings
+		}
+
+		buffer.Write(line)
+		buffer.Write(CRLF)
+
+		offset = next
+	}
+
+	if !sipfrag {
+		warnings = append(warnings, warn(number+1, len(payload), "missing blank line after headers"))
+	}
+
+	buffer.Write(CRLF)
+
+	return buffer.Bytes(), warnings
+}
+
+// repairStartLine removes the whitespace at the end of the start line and between the elements of a request line,
+// and writes the version in upper case, returning the repaired line and the reason of each repair.
+func repairStartLine(line []byte) ([]byte, []string) {
+	var reasons []string
+
+	start := string(line)
+
+	if trimmed := strings.TrimRight(start, " \t"); trimmed != start {
+		start = trimmed
+		reasons = append(reasons, "trailing whitespace in the start line")
+	}
+
+	fields := strings.Fields(start)
+
+	if len(fields) == 0 {
+		return []byte(start), reasons
+	}
+
+	if strings.EqualFold(fields[0], SIPVersion) {
+		if fields[0] != SIPVersion {
+			start = SIPVersion + start[len(fields[0]):]
+			reasons = append(reasons, "version in lower case")
+		}
+
+		return []byte(start), reasons
+	}
+
+	if len(fields) != 3 {
+		return []byte(start), reasons
+	}
+
+	if joined := strings.Join(fields, " "); joined != start {
+		start = joined
+		reasons = append(reasons, "extra whitespace in the request line")
+	}
+
+	if fields[2] != SIPVersion && strings.EqualFold(fields[2], SIPVersion) {
+		start = strings.TrimSuffix(start, fields[2]) + SIPVersion
+		reasons = append(reasons, "version in lower case")
+	}
+
+	return []byte(start), reasons
+}
+
+// warn returns a warning about a deviation found at line, which starts at offset.
+func warn(line int, offset int, reason string) *ParseError {
+	return &ParseError{Line: line, Offset: offset, Reason: reason, Err: ErrNonCompliantSipMessage}
+}
@@ -0,0 +1,137 @@
+package message
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalWithLenientParsing(t *testing.T) {
+	table := []struct {
+		input    []byte
+		want     *Message
+		warnings []*ParseError
+	}{
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\n" +
+				"Call-ID: a84b4c76e66710\n" +
+				"Content-Length: 6\n" +
+				"\n" +
+				"Hello\n"),
+			want: &Message{
+				Kind:     Request,
+				Metadata: Metadata{"method": "OPTIONS", "uri": "sip:bob@biloxi.com", "version": "SIP/2.0"},
+				Request:  &RequestLine{Method: MethodOptions, URI: sipURI("sip:bob@biloxi.com"), Version: SIPVersion},
+				Headers:  Headers{"Call-ID": {"a84b4c76e66710"}, "Content-Length": {"6"}},
+				Order:    []string{"Call-ID", "Content-Length"},
+				Body:     []byte("Hello\n"),
+			},
+			warnings: []*ParseError{
+				{Line: 1, Offset: 0, Reason: "line ends with a bare LF", Err: ErrNonCompliantSipMessage},
+			},
+		},
+		{
+			input: []byte("OPTIONS  sip:bob@biloxi.com \tsip/2.0 \r\n" +
+				"Call-ID: a84b4c76e66710\r\n" +
+				"\r\n"),
+			want: &Message{
+				Kind:     Request,
+				Metadata: Metadata{"method": "OPTIONS", "uri": "sip:bob@biloxi.com", "version": "SIP/2.0"},
+				Request:  &RequestLine{Method: MethodOptions, URI: sipURI("sip:bob@biloxi.com"), Version: SIPVersion},
+				Headers:  Headers{"Call-ID": {"a84b4c76e66710"}},
+				Order:    []string{"Call-ID"},
+			},
+			warnings: []*ParseError{
+				{Line: 1, Offset: 0, Reason: "trailing whitespace in the start line", Err: ErrNonCompliantSipMessage},
+				{Line: 1, Offset: 0, Reason: "extra whitespace in the request line", Err: ErrNonCompliantSipMessage},
+				{Line: 1, Offset: 0, Reason: "version in lower case", Err: ErrNonCompliantSipMessage},
+			},
+		},
+		{
+			input: []byte("sip/2.0 200 OK\r\n" +
+				"Call-ID: a84b4c76e66710\r\n"),
+			want: &Message{
+				Kind:     Response,
+				Metadata: Metadata{"version": "SIP/2.0", "code": "200", "reason": "OK"},
+				Status:   &StatusLine{Version: SIPVersion, Code: 200, Reason: "OK"},
+				Headers:  Headers{"Call-ID": {"a84b4c76e66710"}},
+				Order:    []string{"Call-ID"},
+			},
+			warnings: []*ParseError{
+				{Line: 1, Offset: 0, Reason: "version in lower case", Err: ErrNonCompliantSipMessage},
+				{Line: 3, Offset: 41, Reason: "missing blank line after headers", Err: ErrNonCompliantSipMessage},
+			},
+		},
+		{
+			input: []byte("SIP/2.0 486 Busy Here\r\n" +
+				"Call-ID: a84b4c76e66710"),
+			want: &Message{
+				Kind:     Response,
+				Metadata: Metadata{"version": "SIP/2.0", "code": "486", "reason": "Busy Here"},
+				Status:   &StatusLine{Version: SIPVersion, Code: 486, Reason: "Busy Here"},
+				Headers:  Headers{"Call-ID": {"a84b4c76e66710"}},
+				Order:    []string{"Call-ID"},
+			},
+			warnings: []*ParseError{
+				{Line: 3, Offset: 46, Reason: "missing blank line after headers", Err: ErrNonCompliantSipMessage},
+			},
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			got := new(Message)
+			err := Unmarshal(test.input, got, WithLenientParsing())
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, got.Warnings, test.warnings)
+
+			got.Warnings = nil
+
+			assert.Equal(t, got, test.want)
+
+			assert.ErrorIs(t, Unmarshal(test.input, new(Message)), ErrInvalidSipMessage)
+		})
+	}
+}
+
+func TestUnmarshalWithLenientParsingOfCompliantMessages(t *testing.T) {
+	for _, input := range []string{decoderInvite, decoderResponse, wsinv} {
+		want := new(Message)
+
+		assert.Nil(t, Unmarshal([]byte(input), want))
+
+		got := new(Message)
+
+		assert.Nil(t, Unmarshal([]byte(input), got, WithLenientParsing()))
+
+		assert.Nil(t, got.Warnings)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestUnmarshalWithLenientParsingOfInvalidMessages(t *testing.T) {
+	table := []struct {
+		input []byte
+	}{
+		{input: []byte("")},
+		{input: []byte("\n\n")},
+		{input: []byte("OPTIONS sip:bob@biloxi.com SIP/3.0\n\n")},
+		{input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\nWatson come here\n\n")},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			err := Unmarshal(test.input, new(Message), WithLenientParsing())
+
+			assert.ErrorIs(t, err, ErrInvalidSipMessage, "lenient parsing does not repair invalid messages")
+		})
+	}
+
+	err := Unmarshal([]byte("OPTIONS sip:bob@biloxi.com SIP/2.0\nContent-Length: 10\n\nHello"), new(Message), WithLenientParsing())
+
+	assert.ErrorIs(t, err, ErrInvalidBodyOnSIPMessage)
+}
@@ -0,0 +1,66 @@
+package message
+
+import "fmt"
+
+// ErrMessageTooLarge occurs when a message is longer than the MessageSize limit.
+var ErrMessageTooLarge = fmt.Errorf("SIP message too large")
+
+// ErrTooManyHeaders occurs when a message has more header lines than the Headers limit.
+var ErrTooManyHeaders = fmt.Errorf("too many headers on SIP message")
+
+// ErrHeaderLineTooLong occurs when a line of the start line or headers is longer than the HeaderLineLength limit.
+var ErrHeaderLineTooLong = fmt.Errorf("header line too long on SIP message")
+
+// ErrTooManyHeaderValues occurs when a header has more values than the ValuesPerHeader limit.
+var ErrTooManyHeaderValues = fmt.Errorf("too many values of a header on SIP message")
+
+// ErrBodyTooLarge occurs when the body of a message, or its Content-Length header, exceeds the BodySize limit.
+var ErrBodyTooLarge = fmt.Errorf("SIP message body too large")
+
+// Limits bounds the resources spent on parsing a message, so that a crafted message cannot make Unmarshal or a
+// Decoder allocate without bound. Each limit is reported with its own error, wrapped in a *ParseError. A zero limit
+// is not enforced.
+type Limits struct {
+	// MessageSize is the maximum number of octets of a message, including its body.
+	MessageSize int
+
+	// Headers is the maximum number of header lines, not counting continuation lines.
+	Headers int
+
+	// HeaderLineLength is the maximum number of octets of the start line and of each header line, without the CRLF.
+	HeaderLineLength int
+
+	// ValuesPerHeader is the maximum number of values of a header, summed over all of its header lines.
+	ValuesPerHeader int
+
+	// BodySize is the maximum number of octets of a body.
+	BodySize int
+}
+
+// DefaultLimits are the limits enforced when none are given. They are large enough for any message that fits in
+// a UDP datagram.
+var DefaultLimits = Limits{
+	MessageSize:      65535,
+	Headers:          256,
+	HeaderLineLength: 8192,
+	ValuesPerHeader:  256,
+	BodySize:         65535,
+}
+
+// WithLimits makes Unmarshal and the Decoder enforce limits instead of DefaultLimits. Use WithLimits(Limits{}) to
+// enforce none.
+func WithLimits(limits Limits) UnmarshalOption {
+	return func(options *unmarshalOptions) {
+		options.limits = limits
+	}
+}
+
+// exceeds reports whether value is over limit, when limit is enforced.
+func exceeds(value int, limit int) bool {
+	return limit > 0 && value > limit
+}
+
+// overLimit returns a *ParseError without location for a value over the limit classified by err.
+func overLimit(err error, what string, value int, limit int) *ParseError {
+	return &ParseError{Reason: fmt.Sprintf("%s %d exceeds the limit of %d", what, value, limit), Err: err}
+}
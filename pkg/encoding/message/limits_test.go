@@ -0,0 +1,211 @@
+package message
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var limitsRequest = "OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+	"Via: SIP/2.0/TCP pc33.atlanta.com;branch=z9hG4bK776asdhds, SIP/2.0/TCP pc34.atlanta.com;branch=z9hG4bK776asdhdt\r\n" +
+	"Via: SIP/2.0/TCP pc35.atlanta.com;branch=z9hG4bK776asdhdu\r\n" +
+	"Call-ID: a84b4c76e66710\r\n" +
+	"Content-Length: 11\r\n" +
+	"\r\n" +
+	"Hello World"
+
+// limitsCases holds the error of each limit exceeded by limitsRequest.
+var limitsCases = []struct {
+	name   string
+	limits Limits
+	err    error
+	want   *ParseError
+}{
+	{
+		name:   "message size",
+		limits: Limits{MessageSize: len(limitsRequest) - 1},
+		want:   &ParseError{Reason: "message size 266 exceeds the limit of 265", Err: ErrMessageTooLarge},
+	},
+	{
+		name:   "header count",
+		limits: Limits{Headers: 3},
+		want:   &ParseError{Line: 5, Offset: 233, Reason: "header count 4 exceeds the limit of 3", Err: ErrTooManyHeaders},
+	},
+	{
+		name:   "start line length",
+		limits: Limits{HeaderLineLength: 33},
+		want:   &ParseError{Line: 1, Reason: "line length 34 exceeds the limit of 33", Err: ErrHeaderLineTooLong},
+	},
+	{
+		name:   "header line length",
+		limits: Limits{HeaderLineLength: 100},
+		want:   &ParseError{Line: 2, Offset: 36, Reason: "line length 111 exceeds the limit of 100", Err: ErrHeaderLineTooLong},
+	},
+	{
+		name:   "values of a header",
+		limits: Limits{ValuesPerHeader: 2},
+		want:   &ParseError{Line: 3, Offset: 149, Header: "Via", Reason: "value count 3 exceeds the limit of 2", Err: ErrTooManyHeaderValues},
+	},
+	{
+		name:   "body size",
+		limits: Limits{BodySize: 10},
+		want:   &ParseError{Line: 7, Offset: 255, Header: "Content-Length", Reason: "body size 11 exceeds the limit of 10", Err: ErrBodyTooLarge},
+	},
+}
+
+func TestUnmarshalWithLimits(t *testing.T) {
+	for _, tc := range limitsCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got *ParseError
+
+			err := Unmarshal([]byte(limitsRequest), new(Message), WithLimits(tc.limits))
+
+			assert.ErrorIs(t, err, tc.want.Err)
+
+			assert.True(t, errors.As(err, &got))
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestUnmarshalViewWithLimits(t *testing.T) {
+	for _, tc := range limitsCases {
+		if tc.limits.ValuesPerHeader > 0 {
+			// A View does not split header values
+			continue
+		}
+
+		t.Run(tc.name, func(t *testing.T) {
+			var got *ParseError
+
+			err := UnmarshalView([]byte(limitsRequest), new(View), WithLimits(tc.limits))
+
+			assert.ErrorIs(t, err, tc.want.Err)
+
+			assert.True(t, errors.As(err, &got))
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("body size without Content-Length", func(t *testing.T) {
+		payload := []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\nCall-ID: a84b4c76e66710\r\n\r\nHello World")
+
+		err := UnmarshalView(payload, new(View), WithLimits(Limits{BodySize: 10}))
+
+		assert.Equal(t, &ParseError{Line: 4, Offset: 63, Header: "Content-Length", Reason: "body size 11 exceeds the limit of 10", Err: ErrBodyTooLarge}, err)
+	})
+
+	t.Run("default limits", func(t *testing.T) {
+		payload := []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" + strings.Repeat("X-Padding: 1\r\n", DefaultLimits.Headers+1) + "\r\n")
+
+		assert.ErrorIs(t, UnmarshalView(payload, new(View)), ErrTooManyHeaders)
+
+		assert.Nil(t, UnmarshalView(payload, new(View), WithLimits(Limits{})))
+	})
+}
+
+func TestUnmarshalWithinLimits(t *testing.T) {
+	exact := Limits{MessageSize: len(limitsRequest), Headers: 4, HeaderLineLength: 111, ValuesPerHeader: 3, BodySize: 11}
+
+	for _, limits := range []Limits{DefaultLimits, exact, {}} {
+		got := new(Message)
+
+		assert.Nil(t, Unmarshal([]byte(limitsRequest), got, WithLimits(limits)))
+
+		assert.Equal(t, []byte("Hello World"), got.Body)
+	}
+
+	large := limitsRequest[:len(limitsRequest)-len("Content-Length: 11\r\n\r\nHello World")] +
+		"Content-Length: 70000\r\n\r\n" + strings.Repeat("a", 70000)
+
+	assert.ErrorIs(t, Unmarshal([]byte(large), new(Message)), ErrMessageTooLarge, "the default limits apply")
+
+	assert.Nil(t, Unmarshal([]byte(large), new(Message), WithLimits(Limits{})))
+}
+
+// endless is a reader that never ends, repeating the same octet.
+type endless byte
+
+func (reader endless) Read(buffer []byte) (int, error) {
+	for index := range buffer {
+		buffer[index] = byte(reader)
+	}
+
+	return len(buffer), nil
+}
+
+func TestDecoderWithLimits(t *testing.T) {
+	table := []struct {
+		name   string
+		input  io.Reader
+		limits Limits
+		err    error
+	}{
+		{
+			name:   "endless line",
+			input:  io.MultiReader(strings.NewReader("OPTIONS "), endless('a')),
+			limits: DefaultLimits,
+			err:    ErrHeaderLineTooLong,
+		},
+		{
+			name:   "endless headers",
+			input:  io.MultiReader(strings.NewReader("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n"), endless('\t')),
+			limits: Limits{MessageSize: 65535},
+			err:    ErrMessageTooLarge,
+		},
+		{
+			name:   "too many headers",
+			input:  strings.NewReader("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" + strings.Repeat("Subject: Hello\r\n", 300)),
+			limits: DefaultLimits,
+			err:    ErrTooManyHeaders,
+		},
+		{
+			name:   "huge Content-Length",
+			input:  strings.NewReader("OPTIONS sip:bob@biloxi.com SIP/2.0\r\nContent-Length: 999999999999\r\n\r\n"),
+			limits: DefaultLimits,
+			err:    ErrBodyTooLarge,
+		},
+		{
+			name:   "message larger than its limit",
+			input:  strings.NewReader(limitsRequest),
+			limits: Limits{MessageSize: len(limitsRequest) - 1},
+			err:    ErrMessageTooLarge,
+		},
+		{
+			name:   "values of a header",
+			input:  strings.NewReader(limitsRequest),
+			limits: Limits{ValuesPerHeader: 2},
+			err:    ErrTooManyHeaderValues,
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			decoder := NewDecoder(tc.input, WithLimits(tc.limits))
+
+			got, err := decoder.Decode()
+
+			assert.Nil(t, got)
+
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestDecoderPassesOptionsToUnmarshal(t *testing.T) {
+	decoder := NewDecoder(bytes.NewBufferString(strings.Replace(limitsRequest, "SIP/2.0\r\n", "sip/2.0\r\n", 1)), WithLenientParsing())
+
+	got, err := decoder.Decode()
+
+	assert.Nil(t, err)
+
+	assert.Len(t, got.Warnings, 1)
+
+	assert.Equal(t, "SIP/2.0", got.Metadata["version"])
+}
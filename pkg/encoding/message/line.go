@@ -0,0 +1,306 @@
+package message
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/otoru/party/pkg/encoding/uri"
+)
+
+// RequestLine is the typed view of the start line of a request.
+type RequestLine struct {
+	Method  Method
+	URI     *uri.URI
+	Version string
+}
+
+// StatusLine is the typed view of the start line of a response.
+type StatusLine struct {
+	Version string
+	Code    StatusCode
+	Reason  string
+}
+
+// RequestLine returns the typed request line of message, or its request line parsed from its metadata when it has
+// none or its metadata was changed since, such as when it was built from Metadata. It returns an error for a Request-URI that is not a SIP or SIPS
+// URI, such as a tel URI, which RequestURI returns instead.
+func (message *Message) RequestLine() (*RequestLine, error) {
+	if message.Kind != Request {
+		return nil, ErrInvalidSipMessage
+	}
+
+	if line := message.request(); line != nil {
+		request := *line
+
+		return &request, nil
+	}
+
+	return parseRequestLine(message.Metadata)
+}
+
+// RequestURI returns the parsed Request-URI of message, whatever its scheme, while RequestLine only accepts SIP and
+// SIPS URIs.
+func (message *Message) RequestURI() (uri.AbsoluteURI, error) {
+	if message.Kind != Request {
+		return nil, ErrInvalidSipMessage
+	}
+
+	if line := message.request(); line != nil && line.URI != nil {
+		return line.URI, nil
+	}
+
+	target, err := uri.Parse(message.Metadata["uri"])
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid Request-URI %q", ErrInvalidSipMessage, message.Metadata["uri"])
+	}
+
+	return target, nil
+}
+
+// SetRequestLine turns message into a request with line as its request line, from which its metadata is derived. The
+// version defaults to SIPVersion when empty.
+func (message *Message) SetRequestLine(line *RequestLine) error {
+	if line == nil || line.Method == "" {
+		return ErrOnGenerateSIPMessage
+	}
+
+	target, err := uri.Marshal(line.URI)
+
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrOnGenerateSIPMessage, err)
+	}
+
+	request := *line
+
+	if request.Version == "" {
+		request.Version = SIPVersion
+	}
+
+	message.Kind = Request
+	message.Request = &request
+	message.Status = nil
+	message.Metadata = Metadata{
+		"method":  string(request.Method),
+		"uri":     target,
+		"version": request.Version,
+	}
+
+	return nil
+}
+
+// StatusLine returns the typed status line of message, or its status line parsed from its metadata when it has none
+// or its metadata was changed since, such as when it was built from Metadata.
+func (message *Message) StatusLine() (*StatusLine, error) {
+	if message.Kind != Response {
+		return nil, ErrInvalidSipMessage
+	}
+
+	if line := message.status(); line != nil {
+		status := *line
+
+		return &status, nil
+	}
+
+	return parseStatusLine(message.Metadata)
+}
+
+// SetStatusLine turns message into a response with line as its status line, from which its metadata is derived. The
+// version defaults to SIPVersion when empty, and the reason to the default phrase of the code, or of its class when
+// the code has none.
+func (message *Message) SetStatusLine(line *StatusLine) error {
+	if line == nil || line.Code < 100 || line.Code > 699 {
+		return ErrOnGenerateSIPMessage
+	}
+
+	status := *line
+
+	if status.Version == "" {
+		status.Version = SIPVersion
+	}
+
+	if status.Reason == "" {
+		status.Reason = ReasonPhrase(status.Code)
+	}
+
+	if status.Reason == "" {
+		status.Reason = classPhrase(status.Code)
+	}
+
+	message.Kind = Response
+	message.Status = &status
+	message.Request = nil
+	message.Metadata = Metadata{
+		"version": status.Version,
+		"code":    strconv.Itoa(int(status.Code)),
+		"reason":  status.Reason,
+	}
+
+	return nil
+}
+
+// Method returns the method of a request, or an empty string if message is not a request.
+func (message *Message) Method() Method {
+	if message.Kind != Request {
+		return ""
+	}
+
+	if line := message.request(); line != nil {
+		return line.Method
+	}
+
+	return Method(message.Metadata["method"])
+}
+
+// StatusCode returns the status code of a response, or 0 if message is not a response or its code is invalid.
+func (message *Message) StatusCode() StatusCode {
+	if message.Kind != Response {
+		return 0
+	}
+
+	if line := message.status(); line != nil {
+		return line.Code
+	}
+
+	code, err := parseStatusCode(message.Metadata["code"])
+
+	if err != nil {
+		return 0
+	}
+
+	return code
+}
+
+// startLine returns the start line of message, written from its metadata, which keeps the Request-URI as received and
+// wins over a typed line it no longer agrees with, or from its typed request or status line when it has no metadata.
+func (message *Message) startLine() (string, error) {
+	switch message.Kind {
+	case Request:
+		if line := message.Request; line != nil && len(message.Metadata) == 0 {
+			target, err := uri.Marshal(line.URI)
+
+			if err != nil {
+				return "", fmt.Errorf("%w: %s", ErrOnGenerateSIPMessage, err)
+			}
+
+			return string(line.Method) + " " + target + " " + line.Version, nil
+		}
+
+		if err := ValidateMetadata(message.Metadata, []string{"method", "uri", "version"}); err != nil {
+			return "", err
+		}
+
+		return message.Metadata["method"] + " " + message.Metadata["uri"] + " " + message.Metadata["version"], nil
+	case Response:
+		if line := message.Status; line != nil && len(message.Metadata) == 0 {
+			return line.Version + " " + strconv.Itoa(int(line.Code)) + " " + line.Reason, nil
+		}
+
+		if err := ValidateMetadata(message.Metadata, []string{"version", "code", "reason"}); err != nil {
+			return "", err
+		}
+
+		return message.Metadata["version"] + " " + message.Metadata["code"] + " " + message.Metadata["reason"], nil
+	}
+
+	return "", ErrInvalidSipMessage
+}
+
+// request returns the typed request line of message, unless its metadata holds a different request line, such as
+// after a caller edited Metadata["uri"], in which case the metadata wins and nil is returned.
+func (message *Message) request() *RequestLine {
+	line, metadata := message.Request, message.Metadata
+
+	if line == nil || metadata["method"] == "" && metadata["uri"] == "" && metadata["version"] == "" {
+		return line
+	}
+
+	if metadata["method"] != string(line.Method) || metadata["version"] != line.Version {
+		return nil
+	}
+
+	if target := metadata["uri"]; target != line.URI.String() {
+		// The metadata keeps the Request-URI as received, which may differ from its marshalled form
+		parsed := new(uri.URI)
+
+		if err := uri.Unmarshal(target, parsed); err != nil || !reflect.DeepEqual(parsed, line.URI) {
+			return nil
+		}
+	}
+
+	return line
+}
+
+// status returns the typed status line of message, unless its metadata holds a different status line, in which case
+// the metadata wins and nil is returned.
+func (message *Message) status() *StatusLine {
+	line, metadata := message.Status, message.Metadata
+
+	if line == nil || metadata["version"] == "" && metadata["code"] == "" && metadata["reason"] == "" {
+		return line
+	}
+
+	if metadata["version"] != line.Version || metadata["code"] != strconv.Itoa(int(line.Code)) || metadata["reason"] != line.Reason {
+		return nil
+	}
+
+	return line
+}
+
+// parseRequestLine parses the request line held by metadata.
+func parseRequestLine(metadata Metadata) (*RequestLine, error) {
+	if err := ValidateMetadata(metadata, []string{"method", "uri", "version"}); err != nil {
+		return nil, err
+	}
+
+	target := new(uri.URI)
+
+	if err := uri.Unmarshal(metadata["uri"], target); err != nil {
+		if _, err := uri.Parse(metadata["uri"]); err == nil {
+			return nil, fmt.Errorf("%w: Request-URI %q is not a SIP or SIPS URI, use RequestURI", ErrInvalidSipMessage, metadata["uri"])
+		}
+
+		return nil, fmt.Errorf("%w: invalid Request-URI %q", ErrInvalidSipMessage, metadata["uri"])
+	}
+
+	line := &RequestLine{
+		Method:  Method(metadata["method"]),
+		URI:     target,
+		Version: metadata["version"],
+	}
+
+	return line, nil
+}
+
+// parseStatusLine parses the status line held by metadata.
+func parseStatusLine(metadata Metadata) (*StatusLine, error) {
+	if err := ValidateMetadata(metadata, []string{"version", "code", "reason"}); err != nil {
+		return nil, err
+	}
+
+	code, err := parseStatusCode(metadata["code"])
+
+	if err != nil {
+		return nil, err
+	}
+
+	line := &StatusLine{
+		Version: metadata["version"],
+		Code:    code,
+		Reason:  metadata["reason"],
+	}
+
+	return line, nil
+}
+
+// parseStatusCode parses a three-digit status code between 100 and 699.
+func parseStatusCode(value string) (StatusCode, error) {
+	code, err := strconv.Atoi(value)
+
+	if err != nil || len(value) != 3 || code < 100 || code > 699 {
+		return 0, fmt.Errorf("%w: invalid status code %q", ErrInvalidSipMessage, value)
+	}
+
+	return StatusCode(code), nil
+}
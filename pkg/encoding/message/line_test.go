@@ -0,0 +1,272 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/otoru/party/pkg/encoding/uri"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageRequestLine(t *testing.T) {
+	t.Run("Returns the typed request line", func(t *testing.T) {
+		message := &Message{
+			Kind: Request,
+			Metadata: Metadata{
+				"method":  "INVITE",
+				"uri":     "sip:bob@biloxi.com;transport=tcp",
+				"version": "SIP/2.0",
+			},
+		}
+
+		line, err := message.RequestLine()
+
+		assert.Nil(t, err)
+		assert.Equal(t, MethodInvite, line.Method)
+		assert.Equal(t, "biloxi.com", line.URI.Host)
+		assert.Equal(t, "tcp", line.URI.Parameters["transport"])
+		assert.Equal(t, SIPVersion, line.Version)
+		assert.Equal(t, MethodInvite, message.Method())
+		assert.Equal(t, StatusCode(0), message.StatusCode())
+	})
+
+	t.Run("Returns an error for responses and missing metadata", func(t *testing.T) {
+		line, err := (&Message{Kind: Response}).RequestLine()
+
+		assert.Nil(t, line)
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+
+		line, err = (&Message{Kind: Request, Metadata: Metadata{"method": "INVITE"}}).RequestLine()
+
+		assert.Nil(t, line)
+		assert.ErrorIs(t, err, ErrMissingRequiredMetadataField)
+	})
+
+	t.Run("Returns the Request-URI of any scheme", func(t *testing.T) {
+		for _, target := range []string{"sip:bob@biloxi.com", "tel:+15551234567", "urn:service:sos", "nobarr.url.sip:user@example.com"} {
+			message := &Message{Kind: Request, Metadata: Metadata{"method": "OPTIONS", "uri": target, "version": "SIP/2.0"}}
+
+			got, err := message.RequestURI()
+
+			assert.Nil(t, err)
+			assert.Equal(t, target, got.String())
+		}
+
+		got, err := (&Message{Kind: Response}).RequestURI()
+
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+
+		got, err = (&Message{Kind: Request, Metadata: Metadata{"uri": "tel:7042"}}).RequestURI()
+
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+	})
+
+	t.Run("Returns an error for a tel Request-URI, which RequestURI returns", func(t *testing.T) {
+		message := new(Message)
+
+		err := Unmarshal([]byte("INVITE tel:+15551234567 SIP/2.0\r\nContent-Length: 0\r\n\r\n"), message)
+
+		assert.Nil(t, err)
+		assert.Nil(t, message.Request)
+		assert.Equal(t, MethodInvite, message.Method())
+
+		line, err := message.RequestLine()
+
+		assert.Nil(t, line)
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+		assert.ErrorContains(t, err, "RequestURI")
+
+		target, err := message.RequestURI()
+
+		assert.Nil(t, err)
+		assert.Equal(t, &uri.TelURI{Number: "+15551234567", Parameters: map[string]string{}}, target)
+	})
+
+	t.Run("Sets the request line", func(t *testing.T) {
+		message := new(Message)
+
+		err := message.SetRequestLine(&RequestLine{
+			Method: MethodRegister,
+			URI:    &uri.URI{Scheme: "sip", Host: "registrar.biloxi.com"},
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, Request, message.Kind)
+		assert.Equal(t, Metadata{
+			"method":  "REGISTER",
+			"uri":     "sip:registrar.biloxi.com",
+			"version": "SIP/2.0",
+		}, message.Metadata)
+	})
+
+	t.Run("Returns an error when setting an invalid request line", func(t *testing.T) {
+		message := new(Message)
+
+		assert.ErrorIs(t, message.SetRequestLine(&RequestLine{Method: MethodInvite}), ErrOnGenerateSIPMessage)
+		assert.ErrorIs(t, message.SetRequestLine(nil), ErrOnGenerateSIPMessage)
+	})
+}
+
+func TestMessageStatusLine(t *testing.T) {
+	t.Run("Returns the typed status line", func(t *testing.T) {
+		message := &Message{
+			Kind: Response,
+			Metadata: Metadata{
+				"version": "SIP/2.0",
+				"code":    "180",
+				"reason":  "Ringing",
+			},
+		}
+
+		line, err := message.StatusLine()
+
+		assert.Nil(t, err)
+		assert.Equal(t, &StatusLine{Version: SIPVersion, Code: 180, Reason: "Ringing"}, line)
+		assert.True(t, message.StatusCode().IsProvisional())
+		assert.Equal(t, Method(""), message.Method())
+	})
+
+	t.Run("Returns an error for invalid codes", func(t *testing.T) {
+		for _, code := range []string{"20", "2000", "099", "700", "abc"} {
+			message := &Message{
+				Kind: Response,
+				Metadata: Metadata{
+					"version": "SIP/2.0",
+					"code":    code,
+					"reason":  "OK",
+				},
+			}
+
+			line, err := message.StatusLine()
+
+			assert.Nil(t, line)
+			assert.ErrorIs(t, err, ErrInvalidSipMessage)
+			assert.Equal(t, StatusCode(0), message.StatusCode())
+		}
+	})
+
+	t.Run("Sets the status line with the default reason", func(t *testing.T) {
+		message := new(Message)
+
+		err := message.SetStatusLine(&StatusLine{Code: 486})
+
+		assert.Nil(t, err)
+		assert.Equal(t, Response, message.Kind)
+		assert.Equal(t, Metadata{
+			"version": "SIP/2.0",
+			"code":    "486",
+			"reason":  "Busy Here",
+		}, message.Metadata)
+	})
+
+	t.Run("Sets the status line with the reason of the class of an unknown code", func(t *testing.T) {
+		message := new(Message)
+
+		err := message.SetStatusLine(&StatusLine{Code: 499})
+
+		assert.Nil(t, err)
+		assert.Equal(t, &StatusLine{Version: SIPVersion, Code: 499, Reason: "Request Failure"}, message.Status)
+
+		result, err := Marshal(message)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("SIP/2.0 499 Request Failure\r\nContent-Length: 0\r\n\r\n"), result)
+	})
+
+	t.Run("Returns an error when setting an invalid status line", func(t *testing.T) {
+		message := new(Message)
+
+		assert.ErrorIs(t, message.SetStatusLine(&StatusLine{Code: 42}), ErrOnGenerateSIPMessage)
+	})
+}
+
+func TestMessageTypedStartLine(t *testing.T) {
+	t.Run("Unmarshal stores the typed start line", func(t *testing.T) {
+		message := new(Message)
+
+		err := Unmarshal([]byte("SIP/2.0 180 Ringing\r\nContent-Length: 0\r\n\r\n"), message)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &StatusLine{Version: SIPVersion, Code: 180, Reason: "Ringing"}, message.Status)
+		assert.Nil(t, message.Request)
+
+		err = Unmarshal([]byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\nContent-Length: 0\r\n\r\n"), message)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &RequestLine{Method: MethodOptions, URI: sipURI("sip:bob@biloxi.com"), Version: SIPVersion}, message.Request)
+		assert.Nil(t, message.Status)
+	})
+
+	t.Run("Marshal writes the typed start line of a message without metadata", func(t *testing.T) {
+		message := &Message{
+			Kind:    Request,
+			Request: &RequestLine{Method: MethodInfo, URI: sipURI("sip:alice@atlanta.com"), Version: SIPVersion},
+		}
+
+		result, err := Marshal(message)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("INFO sip:alice@atlanta.com SIP/2.0\r\nContent-Length: 0\r\n\r\n"), result)
+		assert.Equal(t, MethodInfo, message.Method())
+	})
+
+	t.Run("Marshal writes the metadata edited after Unmarshal", func(t *testing.T) {
+		message := new(Message)
+
+		assert.Nil(t, Unmarshal([]byte("INVITE sip:bob@example.com;transport=tcp;lr SIP/2.0\r\nContent-Length: 0\r\n\r\n"), message))
+
+		result, err := Marshal(message)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "INVITE sip:bob@example.com;transport=tcp;lr SIP/2.0\r\nContent-Length: 0\r\n\r\n", string(result))
+
+		message.Metadata["uri"] = "sip:carol@example.com"
+
+		result, err = Marshal(message)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "INVITE sip:carol@example.com SIP/2.0\r\nContent-Length: 0\r\n\r\n", string(result))
+
+		line, err := message.RequestLine()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "carol", line.URI.User)
+
+		assert.Nil(t, Unmarshal([]byte("SIP/2.0 180 Ringing\r\nContent-Length: 0\r\n\r\n"), message))
+
+		message.Metadata["code"] = "183"
+		message.Metadata["reason"] = "Session Progress"
+
+		result, err = Marshal(message)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "SIP/2.0 183 Session Progress\r\nContent-Length: 0\r\n\r\n", string(result))
+		assert.Equal(t, StatusCode(183), message.StatusCode())
+	})
+
+	t.Run("The returned start line is a copy", func(t *testing.T) {
+		message := new(Message)
+
+		assert.Nil(t, message.SetStatusLine(&StatusLine{Code: 200}))
+
+		line, err := message.StatusLine()
+
+		assert.Nil(t, err)
+
+		line.Code = 486
+
+		assert.Equal(t, StatusCode(200), message.StatusCode())
+	})
+}
+
+// sipURI returns value parsed as a SIP URI, for the request lines expected by tests.
+func sipURI(value string) *uri.URI {
+	target := new(uri.URI)
+
+	if err := uri.Unmarshal(value, target); err != nil {
+		panic(err)
+	}
+
+	return target
+}
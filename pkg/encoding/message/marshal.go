@@ -2,63 +2,61 @@ package message
 
 import (
 	"bytes"
-	"encoding/base64"
-	"fmt"
-	"sort"
+	"strconv"
 )
 
 // Marshal returns the SIP encoding of message.
-func Marshal(message *Message) ([]byte, error) {
+//
+// Header names are written in their canonical format, or in their compact form when WithCompactHeaders is given.
+// Headers are emitted in the canonical order (Via, Route, Record-Route, Max-Forwards, From, To, Call-ID, CSeq,
+// Contact, any other header alphabetically, then Content-Type and Content-Length), or in the order recorded in
+// message.Order when WithPreservedOrder is given.
+//
+// The Content-Length header is always calculated from the body, replacing any value present in the headers, unless
+// the message is encoded as a sipfrag with WithSipfrag.
+func Marshal(message *Message, options ...MarshalOption) ([]byte, error) {
 	var buffer bytes.Buffer
 
-	template := "%s %s %s"
+	settings := newMarshalOptions(options)
 
-	switch message.Kind {
-	case Request:
-		method := message.Metadata["method"]
-		uri := message.Metadata["uri"]
-		version := message.Metadata["version"]
-		buffer.WriteString(fmt.Sprintf(template, method, uri, version))
-	case Response:
-		version := message.Metadata["version"]
-		code := message.Metadata["code"]
-		reason := message.Metadata["reason"]
-		buffer.WriteString(fmt.Sprintf(template, version, code, reason))
-	default:
-		return nil, ErrInvalidSipMessage
+	start, err := message.startLine()
+
+	if err != nil {
+		return nil, err
 	}
 
+	buffer.WriteString(start)
 	buffer.Write(CRLF)
 
-	headers := make([]string, 0, len(message.Headers))
+	headers := make(Headers, len(message.Headers)+1)
 
-	for key := range message.Headers {
-		headers = append(headers, key)
+	for key, values := range message.Headers {
+		headers[key] = values
 	}
 
-	sort.Sort(sort.Reverse(sort.StringSlice(headers)))
+	if !settings.sipfrag {
+		headers.Set("Content-Length", strconv.Itoa(len(message.Body)))
+	}
 
-	for _, key := range headers {
-		values := message.Headers[key]
+	for _, field := range orderedHeaderFields(headers, message.Order, settings.preserveOrder) {
+		name := CanonicalHeaderKey(field.key)
 
-		for _, value := range values {
-			buffer.WriteString(key)
-			buffer.WriteString(": ")
-			buffer.WriteString(value)
-			buffer.Write(CRLF)
+		if settings.compact {
+			name = CompactHeaderKey(field.key)
 		}
-	}
 
-	if message.Body != "" {
-		if body, err := base64.StdEncoding.DecodeString(message.Body); err != nil {
-			return nil, ErrInvalidBodyOnSIPMessage
-		} else {
-			buffer.Write(CRLF)
-			buffer.Write(body)
-		}
-	} else {
+		buffer.WriteString(name)
+		buffer.WriteString(": ")
+		buffer.WriteString(field.value)
 		buffer.Write(CRLF)
 	}
 
+	if settings.sipfrag && len(message.Body) == 0 {
+		return buffer.Bytes(), nil
+	}
+
+	buffer.Write(CRLF)
+	buffer.Write(message.Body)
+
 	return buffer.Bytes(), nil
 }
@@ -28,15 +28,14 @@ func TestMarshalWithValidMessages(t *testing.T) {
 					"Call-ID":        {"abcdefg1234567890"},
 					"Content-Length": {"0"},
 				},
-				Body: "",
 			},
 			want: []byte("SIP/2.0 200 OK\r\n" +
 				"Via: SIP/2.0/UDP 10.0.0.1:5060;branch=z9hG4bKkjshdyff\r\n" +
-				"To: sip:bob@example.com;tag=abc123\r\n" +
 				"From: sip:alice@example.com;tag=123abc\r\n" +
-				"Content-Length: 0\r\n" +
+				"To: sip:bob@example.com;tag=abc123\r\n" +
 				"Call-ID: abcdefg1234567890\r\n" +
-				"CSeq: 1 INVITE\r\n\r\n"),
+				"CSeq: 1 INVITE\r\n" +
+				"Content-Length: 0\r\n\r\n"),
 		},
 		{
 			input: &Message{
@@ -57,18 +56,24 @@ func TestMarshalWithValidMessages(t *testing.T) {
 					"Content-Type":   {"application/sdp"},
 					"Content-Length": {"142"},
 				},
-				Body: "dj0wDQpvPWFsaWNlIDI4OTA4NDQ1MjYgMjg5MDg0MjgwNyBJTiBJUDQgY2xpZW50LmF0bGFudGEuZXhhbXBsZS5jb20NCnM9LQ0KYz1JTiBJUDQgMTkyLjAuMi4xMDENCnQ9MCAwDQptPWF1ZGlvIDQ5MTcwIFJUUC9BVlAgMA0KYT1ydHBtYXA6MCBQQ01VLzgwMDANCg==",
+				Body: []byte("v=0\r\n" +
+					"o=alice 2890844526 2890842807 IN IP4 client.atlanta.example.com\r\n" +
+					"s=-\r\n" +
+					"c=IN IP4 192.0.2.101\r\n" +
+					"t=0 0\r\n" +
+					"m=audio 49170 RTP/AVP 0\r\n" +
+					"a=rtpmap:0 PCMU/8000\r\n"),
 			},
 			want: []byte("INVITE sip:user@example.com SIP/2.0\r\n" +
 				"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bKnashds7\r\n" +
-				"To: Bob <sip:bob@example.com>\r\n" +
 				"Max-Forwards: 70\r\n" +
 				"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
-				"Content-Type: application/sdp\r\n" +
-				"Content-Length: 142\r\n" +
-				"Contact: <sip:alice@client.atlanta.example.com>\r\n" +
+				"To: Bob <sip:bob@example.com>\r\n" +
 				"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
 				"CSeq: 1 INVITE\r\n" +
+				"Contact: <sip:alice@client.atlanta.example.com>\r\n" +
+				"Content-Type: application/sdp\r\n" +
+				"Content-Length: 151\r\n" +
 				"\r\n" +
 				"v=0\r\n" +
 				"o=alice 2890844526 2890842807 IN IP4 client.atlanta.example.com\r\n" +
@@ -91,34 +96,149 @@ func TestMarshalWithValidMessages(t *testing.T) {
 	}
 }
 
+func TestMarshalWithCompactHeaders(t *testing.T) {
+	input := &Message{
+		Kind: Request,
+		Metadata: Metadata{
+			"method":  "BYE",
+			"uri":     "sip:bob@192.0.2.4",
+			"version": "SIP/2.0",
+		},
+		Headers: Headers{
+			"Via":            {"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bKnashds10"},
+			"Max-Forwards":   {"70"},
+			"From":           {"Alice <sip:alice@atlanta.com>;tag=1928301774"},
+			"To":             {"Bob <sip:bob@biloxi.com>;tag=a6c85cf"},
+			"Call-ID":        {"a84b4c76e66710"},
+			"CSeq":           {"231 BYE"},
+			"Content-Length": {"0"},
+		},
+	}
+
+	want := []byte("BYE sip:bob@192.0.2.4 SIP/2.0\r\n" +
+		"v: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bKnashds10\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"f: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+		"t: Bob <sip:bob@biloxi.com>;tag=a6c85cf\r\n" +
+		"i: a84b4c76e66710\r\n" +
+		"CSeq: 231 BYE\r\n" +
+		"l: 0\r\n" +
+		"\r\n")
+
+	result, err := Marshal(input, WithCompactHeaders())
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, want, result)
+}
+
+func TestMarshalWithPreservedOrder(t *testing.T) {
+	input := &Message{
+		Kind: Response,
+		Metadata: Metadata{
+			"version": "SIP/2.0",
+			"code":    "200",
+			"reason":  "OK",
+		},
+		Headers: Headers{
+			"Via":            {"SIP/2.0/UDP server10.biloxi.com;branch=z9hG4bKnashds8", "SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds"},
+			"Record-Route":   {"<sip:server10.biloxi.com;lr>"},
+			"To":             {"Bob <sip:bob@biloxi.com>;tag=a6c85cf"},
+			"From":           {"Alice <sip:alice@atlanta.com>;tag=1928301774"},
+			"Call-ID":        {"a84b4c76e66710"},
+			"CSeq":           {"314159 INVITE"},
+			"Contact":        {"<sip:bob@192.0.2.4>"},
+			"Content-Length": {"0"},
+		},
+		Order: []string{"Call-ID", "CSeq", "To", "From", "Via", "Record-Route", "Via"},
+	}
+
+	want := []byte("SIP/2.0 200 OK\r\n" +
+		"Call-ID: a84b4c76e66710\r\n" +
+		"CSeq: 314159 INVITE\r\n" +
+		"To: Bob <sip:bob@biloxi.com>;tag=a6c85cf\r\n" +
+		"From: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+		"Via: SIP/2.0/UDP server10.biloxi.com;branch=z9hG4bKnashds8\r\n" +
+		"Record-Route: <sip:server10.biloxi.com;lr>\r\n" +
+		"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+		"Contact: <sip:bob@192.0.2.4>\r\n" +
+		"Content-Length: 0\r\n" +
+		"\r\n")
+
+	result, err := Marshal(input, WithPreservedOrder())
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, want, result)
+}
+
+func TestMarshalRoundTripWithPreservedOrder(t *testing.T) {
+	input := []byte("SIP/2.0 180 Ringing\r\n" +
+		"To: Bob <sip:bob@biloxi.com>;tag=a6c85cf\r\n" +
+		"From: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+		"Via: SIP/2.0/UDP server10.biloxi.com;branch=z9hG4bKnashds8\r\n" +
+		"Record-Route: <sip:server10.biloxi.com;lr>\r\n" +
+		"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+		"CSeq: 314159 INVITE\r\n" +
+		"Call-ID: a84b4c76e66710\r\n" +
+		"X-Custom: first\r\n" +
+		"Content-Length: 0\r\n" +
+		"\r\n")
+
+	message := new(Message)
+
+	assert.Nil(t, Unmarshal(input, message))
+
+	result, err := Marshal(message, WithPreservedOrder())
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, input, result)
+}
+
+func TestMarshalWithInsertionOrder(t *testing.T) {
+	message := &Message{
+		Kind: Request,
+		Metadata: Metadata{
+			"method":  "OPTIONS",
+			"uri":     "sip:bob@biloxi.com",
+			"version": "SIP/2.0",
+		},
+	}
+
+	message.AddHeader("call-id", "a84b4c76e66710")
+	message.AddHeader("Via", "SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds")
+	message.AddHeader("X-Removed", "yes")
+	message.SetHeader("v", "SIP/2.0/TCP pc33.atlanta.com;branch=z9hG4bK776asdhds")
+	message.AddHeader("CSeq", "1 OPTIONS")
+	message.DelHeader("x-removed")
+
+	assert.Equal(t, []string{"Call-ID", "Via", "CSeq"}, message.Order)
+
+	message.AddHeader("Via", "SIP/2.0/UDP 192.0.2.1;branch=z9hG4bKnashds8")
+
+	assert.Equal(t, []string{"Call-ID", "Via", "CSeq", "Via"}, message.Order)
+
+	want := []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+		"Call-ID: a84b4c76e66710\r\n" +
+		"Via: SIP/2.0/TCP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+		"CSeq: 1 OPTIONS\r\n" +
+		"Via: SIP/2.0/UDP 192.0.2.1;branch=z9hG4bKnashds8\r\n" +
+		"Content-Length: 0\r\n" +
+		"\r\n")
+
+	result, err := Marshal(message, WithPreservedOrder())
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, want, result)
+}
+
 func TestMarshalWithInvalidMessages(t *testing.T) {
 	table := []struct {
 		input *Message
 		want  error
 	}{
-		{
-			input: &Message{
-				Kind: Request,
-				Metadata: map[string]string{
-					"method":  "INVITE",
-					"uri":     "sip:user@example.com",
-					"version": "SIP/2.0",
-				},
-				Headers: map[string][]string{
-					"Via":            {"SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bKnashds7"},
-					"Max-Forwards":   {"70"},
-					"From":           {"Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl"},
-					"To":             {"Bob <sip:bob@example.com>"},
-					"Call-ID":        {"3848276298220188511@atlanta.example.com"},
-					"CSeq":           {"1 INVITE"},
-					"Contact":        {"<sip:alice@client.atlanta.example.com>"},
-					"Content-Type":   {"application/sdp"},
-					"Content-Length": {"142"},
-				},
-				Body: "SGVsbG8gV29ybGQ=====",
-			},
-			want: ErrInvalidBodyOnSIPMessage,
-		},
 		{
 			input: &Message{
 				Kind: "INVALID",
@@ -135,10 +255,35 @@ func TestMarshalWithInvalidMessages(t *testing.T) {
 					"Call-ID":        {"abcdefg1234567890"},
 					"Content-Length": {"0"},
 				},
-				Body: "",
 			},
 			want: ErrInvalidSipMessage,
 		},
+		{
+			input: &Message{
+				Kind: Response,
+				Metadata: Metadata{
+					"version": "SIP/2.0",
+					"reason":  "OK",
+				},
+				Headers: Headers{
+					"Call-ID": {"abcdefg1234567890"},
+				},
+			},
+			want: ErrMissingRequiredMetadataField,
+		},
+		{
+			input: &Message{
+				Kind: Request,
+				Metadata: Metadata{
+					"method":  "INVITE",
+					"version": "SIP/2.0",
+				},
+				Headers: Headers{
+					"Call-ID": {"abcdefg1234567890"},
+				},
+			},
+			want: ErrMissingRequiredMetadataField,
+		},
 	}
 
 	for index, test := range table {
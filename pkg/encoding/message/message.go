@@ -1,8 +1,15 @@
 // The message package implements SIP message encoding and decoding as defined in RFC 3261.
 package message
 
+import (
+	"strings"
+)
+
 // Metadata holds the intricate information of a SIP message.
 //
+// It is a compatibility view of the start line, derived from the typed request or status line of a message, which
+// should be preferred.
+//
 // If it is a Request, it has the following keys:
 //   - method
 //   - uri
@@ -14,18 +21,102 @@ package message
 //   - reason
 type Metadata map[string]string
 
-// Headers have the headers present in the SIP message, and their representation happens in alphabetical order.
+// Headers have the headers present in the SIP message, keyed by their canonical name (see CanonicalHeaderKey).
 //
 // Headers with more than one value are represented in the order they were added to the list, first to last, top to bottom.
+// Use the Get, Values, Has, Set, Add and Del methods to access headers regardless of the case or form of their names.
 type Headers map[string][]string
 
 // Message is the struct that represents the abstraction of a SIP message
+//
+// Request holds the typed request line of a request, and Status the typed status line of a response. Unmarshal,
+// SetRequestLine and SetStatusLine set them along with Metadata, which stays a working view of the start line: when
+// Metadata is edited so that it no longer agrees with them, the start line is taken from Metadata and they are
+// ignored, so change them with SetRequestLine and SetStatusLine rather than in place. When they are nil, such as for
+// a message built from Metadata or a request whose Request-URI is not a SIP or SIPS URI, the start line is also
+// taken from Metadata. They are not part of the JSON representation.
+//
+// Order holds the canonical name of the header of each value, in the order the values appeared on the wire, or in the
+// order they were added with AddHeader and SetHeader. A name appears once per value, so interleaved headers such as
+// Via, Route, Via keep their relative order. Marshal uses it when WithPreservedOrder is given.
+//
+// Body holds the raw octets of the message body. Its JSON representation is base64 encoded.
+//
+// Warnings holds the deviations from RFC 3261 that Unmarshal repaired when given WithLenientParsing. It is not part
+// of the JSON representation.
 type Message struct {
-	Kind     string   `json:"kind"`
-	Metadata Metadata `json:"metadata"`
-	Headers  Headers  `json:"headers"`
-	Body     string   `json:"body,omitempty"`
+	Kind     string        `json:"kind"`
+	Metadata Metadata      `json:"metadata"`
+	Request  *RequestLine  `json:"-"`
+	Status   *StatusLine   `json:"-"`
+	Headers  Headers       `json:"headers"`
+	Order    []string      `json:"order,omitempty"`
+	Body     []byte        `json:"body,omitempty"`
+	Warnings []*ParseError `json:"-"`
+}
+
+// record appends one entry for a value of the header name to the message order.
+func (message *Message) record(name string) {
+	message.Order = append(message.Order, CanonicalHeaderKey(name))
+}
+
+// AddHeader appends value to the values of the header name, keeping track of the order in which headers are added.
+func (message *Message) AddHeader(name string, value string) {
+	if message.Headers == nil {
+		message.Headers = make(Headers)
+	}
+
+	message.Headers.Add(name, value)
+	message.record(name)
+}
+
+// SetHeader replaces the values of the header name by value, keeping track of the order in which headers are added.
+// The header keeps the position of its first value when it was already present.
+func (message *Message) SetHeader(name string, value string) {
+	if message.Headers == nil {
+		message.Headers = make(Headers)
+	}
+
+	message.Headers.Set(name, value)
+
+	name = CanonicalHeaderKey(name)
+	order := message.Order[:0]
+	found := false
+
+	for _, header := range message.Order {
+		if strings.EqualFold(CanonicalHeaderKey(header), name) {
+			if found {
+				continue
+			}
+
+			found = true
+		}
+
+		order = append(order, header)
+	}
+
+	message.Order = order
+
+	if !found {
+		message.record(name)
+	}
+}
+
+// DelHeader removes the values of the header name, along with their positions in the order.
+func (message *Message) DelHeader(name string) {
+	message.Headers.Del(name)
+
+	name = CanonicalHeaderKey(name)
+	order := message.Order[:0]
+
+	for _, header := range message.Order {
+		if !strings.EqualFold(CanonicalHeaderKey(header), name) {
+			order = append(order, header)
+		}
+	}
+
+	message.Order = order
 }
 
 // MessageFactory is the signature of the functions that create new SIP messages
-type MessageFactory func(Metadata, Headers, string) (*Message, error)
+type MessageFactory func(Metadata, Headers, []byte) (*Message, error)
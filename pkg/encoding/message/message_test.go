@@ -0,0 +1,35 @@
+package message
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageJSONEncodesBodyAsBase64(t *testing.T) {
+	message := &Message{
+		Kind: Request,
+		Metadata: Metadata{
+			"method":  "MESSAGE",
+			"uri":     "sip:bob@biloxi.com",
+			"version": "SIP/2.0",
+		},
+		Headers: Headers{
+			"Content-Type": {"text/plain"},
+		},
+		Body: []byte("Hello World"),
+	}
+
+	result, err := json.Marshal(message)
+
+	assert.Nil(t, err)
+
+	assert.Contains(t, string(result), `"body":"SGVsbG8gV29ybGQ="`)
+
+	got := new(Message)
+
+	assert.Nil(t, json.Unmarshal(result, got))
+
+	assert.Equal(t, message, got)
+}
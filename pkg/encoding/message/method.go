@@ -0,0 +1,23 @@
+package message
+
+// Method is the method of a SIP request. Methods are case-sensitive, and extension methods not listed here are
+// valid as long as they are tokens.
+type Method string
+
+// Methods defined in RFC 3261 and its extensions.
+const (
+	MethodAck       Method = "ACK"
+	MethodBye       Method = "BYE"
+	MethodCancel    Method = "CANCEL"
+	MethodInfo      Method = "INFO"
+	MethodInvite    Method = "INVITE"
+	MethodMessage   Method = "MESSAGE"
+	MethodNotify    Method = "NOTIFY"
+	MethodOptions   Method = "OPTIONS"
+	MethodPrack     Method = "PRACK"
+	MethodPublish   Method = "PUBLISH"
+	MethodRefer     Method = "REFER"
+	MethodRegister  Method = "REGISTER"
+	MethodSubscribe Method = "SUBSCRIBE"
+	MethodUpdate    Method = "UPDATE"
+)
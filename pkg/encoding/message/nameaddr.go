@@ -0,0 +1,57 @@
+package message
+
+import (
+	"fmt"
+
+	"github.com/otoru/party/pkg/encoding/header"
+)
+
+// NameAddr returns the parsed value of the first header name holding a name-addr or addr-spec, such as Refer-To,
+// Reply-To or P-Asserted-Identity.
+func (headers Headers) NameAddr(name string) (*header.NameAddr, error) {
+	if !headers.Has(name) {
+		return nil, ErrMissingRequiredHeader
+	}
+
+	addr := new(header.NameAddr)
+
+	if err := header.UnmarshalNameAddr(headers.Get(name), addr); err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidSipMessage, CanonicalHeaderKey(name), err)
+	}
+
+	return addr, nil
+}
+
+// NameAddrs returns the parsed values of every header name holding a name-addr or addr-spec, such as Contact.
+func (headers Headers) NameAddrs(name string) ([]*header.NameAddr, error) {
+	values := headers.Values(name)
+	result := make([]*header.NameAddr, 0, len(values))
+
+	for _, value := range values {
+		addr := new(header.NameAddr)
+
+		if err := header.UnmarshalNameAddr(value, addr); err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", ErrInvalidSipMessage, CanonicalHeaderKey(name), err)
+		}
+
+		result = append(result, addr)
+	}
+
+	return result, nil
+}
+
+// From returns the parsed value of the From header.
+func (headers Headers) From() (*header.NameAddr, error) {
+	return headers.NameAddr("From")
+}
+
+// To returns the parsed value of the To header.
+func (headers Headers) To() (*header.NameAddr, error) {
+	return headers.NameAddr("To")
+}
+
+// Contact returns the parsed values of the Contact header. A wildcard Contact ("*"), which is only valid in
+// REGISTER requests, is not a name-addr and must be checked for before calling it.
+func (headers Headers) Contact() ([]*header.NameAddr, error) {
+	return headers.NameAddrs("Contact")
+}
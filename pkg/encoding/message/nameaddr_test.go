@@ -0,0 +1,55 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadersNameAddr(t *testing.T) {
+	headers := Headers{
+		"f":       {`"Doe, John" <sip:john@atlanta.com>;tag=1928301774`},
+		"To":      {"sip:bob@biloxi.com"},
+		"Contact": {"<sip:bob@192.0.2.4>;expires=60", "<sip:bob@192.0.2.5>;q=0.5"},
+		"Refer-To": {
+			"Bell, Alexander <sip:a.g.bell@example.com>",
+		},
+	}
+
+	t.Run("Returns the parsed From and To headers", func(t *testing.T) {
+		from, err := headers.From()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Doe, John", from.DisplayName)
+		assert.Equal(t, "1928301774", from.Tag())
+
+		to, err := headers.To()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "bob", to.User)
+		assert.Equal(t, "", to.Tag())
+	})
+
+	t.Run("Returns every parsed Contact", func(t *testing.T) {
+		contacts, err := headers.Contact()
+
+		assert.Nil(t, err)
+		assert.Len(t, contacts, 2)
+		assert.Equal(t, "60", contacts[0].Parameters["expires"])
+		assert.Equal(t, "0.5", contacts[1].Parameters["q"])
+	})
+
+	t.Run("Returns an error if a value is invalid", func(t *testing.T) {
+		addr, err := headers.NameAddr("Refer-To")
+
+		assert.Nil(t, addr)
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+	})
+
+	t.Run("Returns an error if the header is missing", func(t *testing.T) {
+		addr, err := headers.NameAddr("Reply-To")
+
+		assert.Nil(t, addr)
+		assert.ErrorIs(t, err, ErrMissingRequiredHeader)
+	})
+}
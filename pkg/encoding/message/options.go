@@ -0,0 +1,107 @@
+package message
+
+// MarshalOption configures how Marshal encodes a message.
+type MarshalOption func(*marshalOptions)
+
+type marshalOptions struct {
+	compact       bool
+	preserveOrder bool
+	sipfrag       bool
+}
+
+// WithCompactHeaders makes Marshal emit header names in their compact form when one is defined, such as "v" for Via
+// and "i" for Call-ID. By default, header names are emitted in their long form.
+func WithCompactHeaders() MarshalOption {
+	return func(options *marshalOptions) {
+		options.compact = true
+	}
+}
+
+// WithPreservedOrder makes Marshal emit header values in the order recorded in the Order field of the message, which
+// holds the wire order for parsed messages and the insertion order for messages built with AddHeader and SetHeader.
+// Extra values of a header listed in Order, such as a Via added by Headers.PushVia, are emitted at its first entry,
+// and values of headers missing from Order after the others, in the canonical order. Since each value is emitted on
+// a line of its own, comma-separated and folded header lines do not come back byte-exact.
+func WithPreservedOrder() MarshalOption {
+	return func(options *marshalOptions) {
+		options.preserveOrder = true
+	}
+}
+
+// WithSipfrag makes Marshal encode the message as a message/sipfrag body, as defined in RFC 3420: the start line and
+// the headers of the message, followed by the blank line and the body only when the body is not empty. The
+// Content-Length header is not added, since the fragment is delimited by the message that carries it.
+func WithSipfrag() MarshalOption {
+	return func(options *marshalOptions) {
+		options.sipfrag = true
+	}
+}
+
+func newMarshalOptions(options []MarshalOption) *marshalOptions {
+	result := new(marshalOptions)
+
+	for _, option := range options {
+		option(result)
+	}
+
+	return result
+}
+
+// UnmarshalOption configures how Unmarshal parses a message.
+type UnmarshalOption func(*unmarshalOptions)
+
+// parsingMode is how closely Unmarshal follows the grammar of RFC 3261.
+type parsingMode int
+
+const (
+	defaultParsing parsingMode = iota
+	lenientParsing
+	strictParsing
+)
+
+type unmarshalOptions struct {
+	sipfrag bool
+	mode    parsingMode
+	limits  Limits
+}
+
+// WithSipfragParsing makes Unmarshal parse the payload as a message/sipfrag body, as defined in RFC 3420. The start
+// line may be followed by any subset of headers, and the blank line is only required before a body.
+func WithSipfragParsing() UnmarshalOption {
+	return func(options *unmarshalOptions) {
+		options.sipfrag = true
+	}
+}
+
+// WithLenientParsing makes Unmarshal accept the deviations of broken implementations that can be repaired without
+// guessing: lines ending in a bare LF, whitespace at the end of the start line or between the elements of a request
+// line, a version in lower case such as "sip/2.0", and a payload that ends without the blank line. Each deviation is
+// recorded in the Warnings of the message. Errors found after these repairs locate the problem in the repaired
+// payload.
+func WithLenientParsing() UnmarshalOption {
+	return func(options *unmarshalOptions) {
+		options.mode = lenientParsing
+	}
+}
+
+// WithStrictParsing makes Unmarshal enforce the grammar of RFC 3261 section 25 on top of the default checks: the
+// elements of a request line must be separated by single spaces, header values must be UTF-8 text, quoted strings
+// must be well formed, header parameters must be tokens, hosts or quoted strings, the values of headers such as Via,
+// From, To, Contact, Date, Call-ID, Content-Type, Event, Allow and Supported must match their rules, and Date,
+// Expires, Min-Expires and Content-Type must not be repeated. It is meant for validation tools rather than for
+// traffic.
+func WithStrictParsing() UnmarshalOption {
+	return func(options *unmarshalOptions) {
+		options.mode = strictParsing
+	}
+}
+
+func newUnmarshalOptions(options []UnmarshalOption) *unmarshalOptions {
+	result := &unmarshalOptions{limits: DefaultLimits}
+
+	for _, option := range options {
+		option(result)
+	}
+
+	return result
+}
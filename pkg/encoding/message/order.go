@@ -0,0 +1,123 @@
+package message
+
+import (
+	"sort"
+)
+
+// canonicalOrder is the order in which Marshal emits well-known headers by default. Routing headers come first, so
+// that proxies can process a message without reading all of it, and the body headers come last.
+var canonicalOrder = []string{
+	"Via",
+	"Route",
+	"Record-Route",
+	"Max-Forwards",
+	"From",
+	"To",
+	"Call-ID",
+	"CSeq",
+	"Contact",
+}
+
+// trailingOrder holds the headers that Marshal emits after all the others.
+var trailingOrder = []string{
+	"Content-Type",
+	"Content-Length",
+}
+
+// headerRank returns the position of the header name in the canonical order. Headers that are not part of it share
+// the same rank and are sorted alphabetically among themselves.
+func headerRank(name string) int {
+	name = CanonicalHeaderKey(name)
+
+	for index, header := range canonicalOrder {
+		if header == name {
+			return index
+		}
+	}
+
+	for index, header := range trailingOrder {
+		if header == name {
+			return len(canonicalOrder) + 1 + index
+		}
+	}
+
+	return len(canonicalOrder)
+}
+
+// sortHeaderKeys sorts keys in the canonical order.
+func sortHeaderKeys(keys []string) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		left, right := headerRank(keys[i]), headerRank(keys[j])
+
+		if left != right {
+			return left < right
+		}
+
+		return CanonicalHeaderKey(keys[i]) < CanonicalHeaderKey(keys[j])
+	})
+}
+
+// headerField is a single value of a header, as Marshal emits it on its own line.
+type headerField struct {
+	key   string
+	value string
+}
+
+// orderedHeaderFields returns the values of headers in the order Marshal emits them.
+//
+// When preserved is true, the values listed in order come first, in that order: each entry of order stands for the
+// next value of its header. A header with more values than entries, such as after Headers.PushVia, has the extra
+// values emitted at its first entry, so that they stay next to the others. Any other value follows, in the canonical
+// order.
+func orderedHeaderFields(headers Headers, order []string, preserved bool) []headerField {
+	fields := make([]headerField, 0, len(order)+len(headers))
+	emitted := make(map[string]int, len(headers))
+
+	if preserved {
+		keys := make([]string, len(order))
+		entries := make(map[string]int, len(headers))
+
+		for index, name := range order {
+			if key, ok := headers.key(name); ok {
+				keys[index] = key
+				entries[key]++
+			}
+		}
+
+		for _, key := range keys {
+			if key == "" || emitted[key] >= len(headers[key]) {
+				continue
+			}
+
+			count := 1
+
+			if emitted[key] == 0 && len(headers[key]) > entries[key] {
+				count += len(headers[key]) - entries[key]
+			}
+
+			for _, value := range headers[key][emitted[key] : emitted[key]+count] {
+				fields = append(fields, headerField{key: key, value: value})
+			}
+
+			emitted[key] += count
+		}
+	}
+
+	keys := make([]string, 0, len(headers))
+
+	for key := range headers {
+		if emitted[key] < len(headers[key]) {
+			keys = append(keys, key)
+		}
+	}
+
+	sortHeaderKeys(keys)
+
+	for _, key := range keys {
+		for _, value := range headers[key][emitted[key]:] {
+			fields = append(fields, headerField{key: key, value: value})
+		}
+	}
+
+	return fields
+}
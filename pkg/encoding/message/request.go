@@ -14,8 +14,9 @@ package message
 //   - Via
 //   - Call-ID
 //
-// Although RFC 3261 defines the CSeq as a mandatory header, one is generated for you if you do not provide it.
-func CreateSIPRequest(metadata Metadata, headers Headers, body string) (*Message, error) {
+// Although RFC 3261 defines the CSeq as a mandatory header, one with sequence 1 and the method of the request is
+// generated for you if you do not provide it.
+func CreateSIPRequest(metadata Metadata, headers Headers, body []byte) (*Message, error) {
 	message := new(Message)
 
 	message.Kind = Request
@@ -26,10 +27,14 @@ func CreateSIPRequest(metadata Metadata, headers Headers, body string) (*Message
 
 	message.Metadata = metadata
 
-	if err := ValidateHeaders(headers, []string{"CSeq", "To", "From", "Max-Forwards", "Via", "Call-ID"}); err != nil {
+	if err := ValidateHeaders(headers, []string{"To", "From", "Max-Forwards", "Via", "Call-ID"}); err != nil {
 		return nil, err
 	}
 
+	if !headers.Has("CSeq") {
+		headers.SetCSeq(1, Method(metadata["method"]))
+	}
+
 	message.Headers = headers
 
 	message.Body = body
@@ -11,7 +11,7 @@ func TestCreateSIPRequestWithValidData(t *testing.T) {
 	table := []struct {
 		metadata Metadata
 		headers  Headers
-		body     string
+		body     []byte
 		want     *Message
 	}{
 		{
@@ -29,7 +29,7 @@ func TestCreateSIPRequestWithValidData(t *testing.T) {
 				"Max-Forwards":   {"70"},
 				"CSeq":           {"1 INVITE"},
 			},
-			body: "",
+			body: nil,
 			want: &Message{
 				Kind: Request,
 				Metadata: Metadata{
@@ -46,7 +46,37 @@ func TestCreateSIPRequestWithValidData(t *testing.T) {
 					"Max-Forwards":   {"70"},
 					"CSeq":           {"1 INVITE"},
 				},
-				Body: "",
+			},
+		},
+		{
+			metadata: Metadata{
+				"method":  "OPTIONS",
+				"uri":     "sip:user@example.com",
+				"version": "SIP/2.0",
+			},
+			headers: Headers{
+				"Via":          {"SIP/2.0/UDP 10.0.0.1:5060;branch=z9hG4bKkjshdyff"},
+				"To":           {"sip:bob@example.com"},
+				"From":         {"sip:alice@example.com;tag=123abc"},
+				"Call-ID":      {"abcdefg1234567890"},
+				"Max-Forwards": {"70"},
+			},
+			body: nil,
+			want: &Message{
+				Kind: Request,
+				Metadata: Metadata{
+					"method":  "OPTIONS",
+					"uri":     "sip:user@example.com",
+					"version": "SIP/2.0",
+				},
+				Headers: Headers{
+					"Via":          {"SIP/2.0/UDP 10.0.0.1:5060;branch=z9hG4bKkjshdyff"},
+					"To":           {"sip:bob@example.com"},
+					"From":         {"sip:alice@example.com;tag=123abc"},
+					"Call-ID":      {"abcdefg1234567890"},
+					"Max-Forwards": {"70"},
+					"CSeq":         {"1 OPTIONS"},
+				},
 			},
 		},
 	}
@@ -66,7 +96,7 @@ func TestCreateSIPRequestWithInvalidData(t *testing.T) {
 	table := []struct {
 		metadata Metadata
 		headers  Headers
-		body     string
+		body     []byte
 		want     error
 	}{
 		{
@@ -82,7 +112,7 @@ func TestCreateSIPRequestWithInvalidData(t *testing.T) {
 				"Content-Length": {"0"},
 				"Max-Forwards":   {"70"},
 			},
-			body: "",
+			body: nil,
 			want: ErrMissingRequiredMetadataField,
 		},
 		{
@@ -98,7 +128,7 @@ func TestCreateSIPRequestWithInvalidData(t *testing.T) {
 				"Call-ID":        {"abcdefg1234567890"},
 				"Content-Length": {"0"},
 			},
-			body: "",
+			body: nil,
 			want: ErrMissingRequiredHeader,
 		},
 	}
@@ -13,7 +13,7 @@ package message
 //   - Via
 //   - Call-ID
 //   - CSeq
-func CreateSIPResponse(metadata Metadata, headers Headers, body string) (*Message, error) {
+func CreateSIPResponse(metadata Metadata, headers Headers, body []byte) (*Message, error) {
 	message := new(Message)
 	message.Kind = Response
 
@@ -11,7 +11,7 @@ func TestCreateSIPResponseWithValidData(t *testing.T) {
 	table := []struct {
 		metadata Metadata
 		headers  Headers
-		body     string
+		body     []byte
 		want     *Message
 	}{
 		{
@@ -28,7 +28,7 @@ func TestCreateSIPResponseWithValidData(t *testing.T) {
 				"Call-ID":        {"abcdefg1234567890"},
 				"Content-Length": {"0"},
 			},
-			body: "",
+			body: nil,
 			want: &Message{
 				Kind: Response,
 				Metadata: Metadata{
@@ -44,7 +44,6 @@ func TestCreateSIPResponseWithValidData(t *testing.T) {
 					"Call-ID":        {"abcdefg1234567890"},
 					"Content-Length": {"0"},
 				},
-				Body: "",
 			},
 		},
 	}
@@ -64,7 +63,7 @@ func TestCreateSIPResponseWithInvalidData(t *testing.T) {
 	table := []struct {
 		metadata Metadata
 		headers  Headers
-		body     string
+		body     []byte
 		want     error
 	}{
 		{
@@ -73,7 +72,7 @@ func TestCreateSIPResponseWithInvalidData(t *testing.T) {
 				"reason":  "OK",
 			},
 			headers: Headers{},
-			body:    "",
+			body:    nil,
 			want:    ErrMissingRequiredMetadataField,
 		},
 		{
@@ -85,7 +84,7 @@ func TestCreateSIPResponseWithInvalidData(t *testing.T) {
 			headers: Headers{
 				"Via": {"SIP/2.0/UDP 10.0.0.1:5060;branch=z9hG4bKkjshdyff"},
 			},
-			body: "",
+			body: nil,
 			want: ErrMissingRequiredHeader,
 		},
 	}
@@ -0,0 +1,41 @@
+package message
+
+import "fmt"
+
+// The message/sipfrag codec is registered here, since a sipfrag is a SIP message itself.
+func init() {
+	RegisterBodyCodec("message/sipfrag", SipfragCodec{})
+}
+
+// SipfragCodec is the BodyCodec of message/sipfrag bodies, as defined in RFC 3420, which are decoded into and
+// encoded from a *Message. It allows the REFER NOTIFY requests of RFC 3515 to carry the progress of a transfer,
+// such as a fragment holding only the status line "SIP/2.0 180 Ringing".
+type SipfragCodec struct{}
+
+// Unmarshal parses a sipfrag into v, which must be a *Message, using WithSipfragParsing.
+func (SipfragCodec) Unmarshal(contentType string, payload []byte, v any) error {
+	fragment, ok := v.(*Message)
+
+	if !ok {
+		return fmt.Errorf("%w: cannot decode %s into %T", ErrUnsupportedBody, contentType, v)
+	}
+
+	return Unmarshal(payload, fragment, WithSipfragParsing())
+}
+
+// Marshal encodes v, which must be a *Message, using WithSipfrag.
+func (SipfragCodec) Marshal(contentType string, v any) (string, []byte, error) {
+	fragment, ok := v.(*Message)
+
+	if !ok {
+		return "", nil, fmt.Errorf("%w: cannot encode %T as %s", ErrUnsupportedBody, v, contentType)
+	}
+
+	payload, err := Marshal(fragment, WithSipfrag())
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return contentType, payload, nil
+}
@@ -0,0 +1,171 @@
+package message
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalWithSipfragParsing(t *testing.T) {
+	table := []struct {
+		input []byte
+		want  *Message
+	}{
+		{
+			input: []byte("SIP/2.0 180 Ringing"),
+			want: &Message{
+				Kind:     Response,
+				Metadata: Metadata{"version": "SIP/2.0", "code": "180", "reason": "Ringing"},
+				Status:   &StatusLine{Version: SIPVersion, Code: 180, Reason: "Ringing"},
+				Headers:  Headers{},
+			},
+		},
+		{
+			input: []byte("SIP/2.0 200 OK\r\n"),
+			want: &Message{
+				Kind:     Response,
+				Metadata: Metadata{"version": "SIP/2.0", "code": "200", "reason": "OK"},
+				Status:   &StatusLine{Version: SIPVersion, Code: 200, Reason: "OK"},
+				Headers:  Headers{},
+			},
+		},
+		{
+			input: []byte("SIP/2.0 603 Declined\r\n" +
+				"CSeq: 1 INVITE\r\n" +
+				"Subject: Sorry, busy"),
+			want: &Message{
+				Kind:     Response,
+				Metadata: Metadata{"version": "SIP/2.0", "code": "603", "reason": "Declined"},
+				Status:   &StatusLine{Version: SIPVersion, Code: 603, Reason: "Declined"},
+				Headers:  Headers{"CSeq": {"1 INVITE"}, "Subject": {"Sorry, busy"}},
+				Order:    []string{"CSeq", "Subject"},
+			},
+		},
+		{
+			input: []byte("INVITE sip:alice@atlanta.com SIP/2.0\r\n" +
+				"Contact: <sip:alice@pc33.atlanta.com>\r\n" +
+				"Content-Type: text/plain\r\n" +
+				"\r\n" +
+				"Hello"),
+			want: &Message{
+				Kind:     Request,
+				Metadata: Metadata{"method": "INVITE", "uri": "sip:alice@atlanta.com", "version": "SIP/2.0"},
+				Request:  &RequestLine{Method: MethodInvite, URI: sipURI("sip:alice@atlanta.com"), Version: SIPVersion},
+				Headers:  Headers{"Contact": {"<sip:alice@pc33.atlanta.com>"}, "Content-Type": {"text/plain"}},
+				Order:    []string{"Contact", "Content-Type"},
+				Body:     []byte("Hello"),
+			},
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			got := new(Message)
+			err := Unmarshal(test.input, got, WithSipfragParsing())
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, got, test.want)
+
+			if test.want.Body == nil {
+				assert.ErrorIs(t, Unmarshal(test.input, new(Message)), ErrInvalidSipMessage, "a full message needs the blank line")
+			}
+		})
+	}
+}
+
+func TestUnmarshalWithInvalidSipfrags(t *testing.T) {
+	table := []struct {
+		input []byte
+	}{
+		{input: []byte("")},
+		{input: []byte("SIP/2.0 1800 Ringing")},
+		{input: []byte("SIP/2.0 200 OK\r\nCSeq: 1 INVITE\r\nCSeq: 2 INVITE")},
+		{input: []byte("SIP/2.0 200 OK\r\nContent-Length: 10\r\n\r\nHello")},
+		{input: []byte("SIP/2.0 200 OK\r\nWatson come here")},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			err := Unmarshal(test.input, new(Message), WithSipfragParsing())
+
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestMarshalWithSipfrag(t *testing.T) {
+	table := []struct {
+		input *Message
+		want  []byte
+	}{
+		{
+			input: &Message{
+				Kind:     Response,
+				Metadata: Metadata{"version": "SIP/2.0", "code": "180", "reason": "Ringing"},
+				Status:   &StatusLine{Version: SIPVersion, Code: 180, Reason: "Ringing"},
+			},
+			want: []byte("SIP/2.0 180 Ringing\r\n"),
+		},
+		{
+			input: &Message{
+				Kind:     Response,
+				Metadata: Metadata{"version": "SIP/2.0", "code": "603", "reason": "Declined"},
+				Status:   &StatusLine{Version: SIPVersion, Code: 603, Reason: "Declined"},
+				Headers:  Headers{"CSeq": {"1 INVITE"}},
+			},
+			want: []byte("SIP/2.0 603 Declined\r\nCSeq: 1 INVITE\r\n"),
+		},
+		{
+			input: &Message{
+				Kind:     Request,
+				Metadata: Metadata{"method": "MESSAGE", "uri": "sip:bob@biloxi.com", "version": "SIP/2.0"},
+				Request:  &RequestLine{Method: MethodMessage, URI: sipURI("sip:bob@biloxi.com"), Version: SIPVersion},
+				Headers:  Headers{"Content-Type": {"text/plain"}},
+				Body:     []byte("Hello"),
+			},
+			want: []byte("MESSAGE sip:bob@biloxi.com SIP/2.0\r\nContent-Type: text/plain\r\n\r\nHello"),
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			result, err := Marshal(test.input, WithSipfrag())
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, result, test.want)
+
+			again := new(Message)
+
+			assert.Nil(t, Unmarshal(result, again, WithSipfragParsing()))
+
+			assert.Equal(t, again.Metadata, test.input.Metadata)
+		})
+	}
+}
+
+func TestSipfragCodecInNotify(t *testing.T) {
+	progress := new(Message)
+
+	assert.Nil(t, progress.SetStatusLine(&StatusLine{Code: 180}))
+
+	notify := new(Message)
+
+	assert.Nil(t, notify.SetBody("message/sipfrag;version=2.0", progress))
+
+	assert.Equal(t, []byte("SIP/2.0 180 Ringing\r\n"), notify.Body)
+	assert.Equal(t, "message/sipfrag;version=2.0", notify.Headers.Get("Content-Type"))
+	assert.Equal(t, "21", notify.Headers.Get("Content-Length"))
+
+	got := new(Message)
+
+	assert.Nil(t, notify.DecodeBody(got))
+
+	assert.Equal(t, StatusCode(180), got.StatusCode())
+
+	assert.ErrorIs(t, notify.DecodeBody(new(int)), ErrUnsupportedBody)
+
+	assert.ErrorIs(t, notify.SetBody("message/sipfrag", 180), ErrUnsupportedBody)
+}
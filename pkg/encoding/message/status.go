@@ -0,0 +1,127 @@
+package message
+
+// StatusCode is the status code of a SIP response, which is a three-digit integer between 100 and 699.
+type StatusCode int
+
+// IsProvisional reports whether code is a provisional (1xx) response.
+func (code StatusCode) IsProvisional() bool {
+	return code >= 100 && code < 200
+}
+
+// IsSuccess reports whether code is a success (2xx) response.
+func (code StatusCode) IsSuccess() bool {
+	return code >= 200 && code < 300
+}
+
+// IsRedirect reports whether code is a redirection (3xx) response.
+func (code StatusCode) IsRedirect() bool {
+	return code >= 300 && code < 400
+}
+
+// IsFailure reports whether code is a client error (4xx), server error (5xx) or global failure (6xx) response.
+func (code StatusCode) IsFailure() bool {
+	return code >= 400 && code < 700
+}
+
+// IsFinal reports whether code is a final response, which terminates a transaction.
+func (code StatusCode) IsFinal() bool {
+	return code >= 200 && code < 700
+}
+
+// reasonPhrases holds the default reason phrases defined in RFC 3261 section 21 and its extensions.
+var reasonPhrases = map[StatusCode]string{
+	100: "Trying",
+	180: "Ringing",
+	181: "Call Is Being Forwarded",
+	182: "Queued",
+	183: "Session Progress",
+	199: "Early Dialog Terminated",
+	200: "OK",
+	202: "Accepted",
+	204: "No Notification",
+	300: "Multiple Choices",
+	301: "Moved Permanently",
+	302: "Moved Temporarily",
+	305: "Use Proxy",
+	380: "Alternative Service",
+	400: "Bad Request",
+	401: "Unauthorized",
+	402: "Payment Required",
+	403: "Forbidden",
+	404: "Not Found",
+	405: "Method Not Allowed",
+	406: "Not Acceptable",
+	407: "Proxy Authentication Required",
+	408: "Request Timeout",
+	410: "Gone",
+	412: "Conditional Request Failed",
+	413: "Request Entity Too Large",
+	414: "Request-URI Too Long",
+	415: "Unsupported Media Type",
+	416: "Unsupported URI Scheme",
+	417: "Unknown Resource-Priority",
+	420: "Bad Extension",
+	421: "Extension Required",
+	422: "Session Interval Too Small",
+	423: "Interval Too Brief",
+	428: "Use Identity Header",
+	429: "Provide Referrer Identity",
+	433: "Anonymity Disallowed",
+	436: "Bad Identity-Info",
+	437: "Unsupported Certificate",
+	438: "Invalid Identity Header",
+	439: "First Hop Lacks Outbound Support",
+	440: "Max-Breadth Exceeded",
+	469: "Bad Info Package",
+	470: "Consent Needed",
+	480: "Temporarily Unavailable",
+	481: "Call/Transaction Does Not Exist",
+	482: "Loop Detected",
+	483: "Too Many Hops",
+	484: "Address Incomplete",
+	485: "Ambiguous",
+	486: "Busy Here",
+	487: "Request Terminated",
+	488: "Not Acceptable Here",
+	489: "Bad Event",
+	491: "Request Pending",
+	493: "Undecipherable",
+	494: "Security Agreement Required",
+	500: "Server Internal Error",
+	501: "Not Implemented",
+	502: "Bad Gateway",
+	503: "Service Unavailable",
+	504: "Server Time-out",
+	505: "Version Not Supported",
+	513: "Message Too Large",
+	580: "Precondition Failure",
+	600: "Busy Everywhere",
+	603: "Decline",
+	604: "Does Not Exist Anywhere",
+	606: "Not Acceptable",
+	607: "Unwanted",
+}
+
+// ReasonPhrase returns the default reason phrase of code, or an empty string if the code is unknown.
+func ReasonPhrase(code StatusCode) string {
+	return reasonPhrases[code]
+}
+
+// classPhrase returns the name of the class of code, as given in RFC 3261 section 21, for use as the reason phrase
+// of a code without a default one.
+func classPhrase(code StatusCode) string {
+	switch {
+	case code.IsProvisional():
+		return "Provisional"
+	case code.IsSuccess():
+		return "Successful"
+	case code.IsRedirect():
+		return "Redirection"
+	case code >= 400 && code < 500:
+		return "Request Failure"
+	case code >= 500 && code < 600:
+		return "Server Failure"
+	}
+
+	return "Global Failure"
+}
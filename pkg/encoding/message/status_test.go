@@ -0,0 +1,46 @@
+package message
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCodeClasses(t *testing.T) {
+	table := []struct {
+		code        StatusCode
+		provisional bool
+		success     bool
+		redirect    bool
+		failure     bool
+		final       bool
+	}{
+		{code: 100, provisional: true},
+		{code: 183, provisional: true},
+		{code: 200, success: true, final: true},
+		{code: 302, redirect: true, final: true},
+		{code: 486, failure: true, final: true},
+		{code: 503, failure: true, final: true},
+		{code: 603, failure: true, final: true},
+		{code: 99},
+		{code: 700},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			assert.Equal(t, test.provisional, test.code.IsProvisional())
+			assert.Equal(t, test.success, test.code.IsSuccess())
+			assert.Equal(t, test.redirect, test.code.IsRedirect())
+			assert.Equal(t, test.failure, test.code.IsFailure())
+			assert.Equal(t, test.final, test.code.IsFinal())
+		})
+	}
+}
+
+func TestReasonPhrase(t *testing.T) {
+	assert.Equal(t, "Ringing", ReasonPhrase(180))
+	assert.Equal(t, "Call/Transaction Does Not Exist", ReasonPhrase(481))
+	assert.Equal(t, "Server Time-out", ReasonPhrase(504))
+	assert.Equal(t, "", ReasonPhrase(299))
+}
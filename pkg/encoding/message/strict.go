@@ -0,0 +1,285 @@
+package message
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/otoru/party/pkg/encoding/header"
+	"github.com/otoru/party/pkg/encoding/internal/grammar"
+)
+
+// nameAddrHeaders holds the headers whose values are a name-addr or an addr-spec.
+var nameAddrHeaders = map[string]bool{
+	"Contact":      true,
+	"From":         true,
+	"Record-Route": true,
+	"Reply-To":     true,
+	"Route":        true,
+	"To":           true,
+}
+
+// strictSingleHeaders holds the headers that carry a single value, beyond the singleHeaders, and so must not appear
+// more than once in a message.
+var strictSingleHeaders = map[string]bool{
+	"Content-Type": true,
+	"Date":         true,
+	"Expires":      true,
+	"Min-Expires":  true,
+}
+
+// tokenListHeaders holds the headers whose elements are a single token, and whether the header may be empty.
+var tokenListHeaders = map[string]bool{
+	"Allow":            true,
+	"Allow-Events":     false,
+	"Content-Encoding": false,
+	"Proxy-Require":    false,
+	"Require":          false,
+	"Supported":        true,
+	"Unsupported":      false,
+}
+
+// checkStrictStartLine checks start, the start line of message, against the grammar of RFC 3261 section 25.1, beyond
+// what unmarshalStartLine does: the elements of a request line must be separated by exactly one space, the reason
+// phrase must be UTF-8 text, and a Request-URI of a scheme other than SIP and SIPS must only have the characters of
+// an absoluteURI.
+func checkStrictStartLine(start string, message *Message) error {
+	if message.Kind == Response {
+		if !isText(message.Metadata["reason"]) {
+			return &ParseError{Line: 1, Reason: fmt.Sprintf("invalid reason phrase %q", message.Metadata["reason"]), Err: ErrInvalidSipMessage}
+		}
+
+		return nil
+	}
+
+	if start != message.Metadata["method"]+" "+message.Metadata["uri"]+" "+message.Metadata["version"] {
+		return &ParseError{Line: 1, Reason: "malformed request line", Err: ErrInvalidSipMessage}
+	}
+
+	if target := message.Metadata["uri"]; !isURIText(target) {
+		return &ParseError{Line: 1, Reason: fmt.Sprintf("invalid Request-URI %q", target), Err: ErrInvalidSipMessage}
+	}
+
+	return nil
+}
+
+// checkStrictHeader checks a single value of the header key against the grammar of RFC 3261 section 25.1 before it
+// is added to message, returning a *ParseError without location if it does not match or the header cannot be
+// repeated.
+func checkStrictHeader(message *Message, key string, value string) error {
+	if strictSingleHeaders[key] && message.Headers.Has(key) {
+		return &ParseError{Reason: "header must not be repeated", Err: ErrInvalidSipMessage}
+	}
+
+	reason := ""
+
+	switch {
+	case !utf8.ValidString(value):
+		reason = "header value is not UTF-8 text"
+	case key == "Call-ID":
+		// The words of a Call-ID may hold quotes that do not start a quoted string
+		if !isCallID(value) {
+			reason = fmt.Sprintf("invalid Call-ID %q", value)
+		}
+	case !hasValidQuotes(value):
+		reason = "control character or malformed quoted string"
+	case key == "Content-Type" || (key == "Accept" && value != ""):
+		if !isMediaType(value) {
+			reason = fmt.Sprintf("invalid media type %q", value)
+		}
+	case key == "Event":
+		name, params, _ := strings.Cut(value, ";")
+
+		if !grammar.IsToken(strings.TrimSpace(name)) || !hasValidParams(params) {
+			reason = fmt.Sprintf("invalid event %q", value)
+		}
+	case key == "Date":
+		// RFC 3261 section 20.17 restricts the Date header to the RFC 1123 format in GMT
+		if _, err := time.Parse(time.RFC1123, value); err != nil || !strings.HasSuffix(value, " GMT") {
+			reason = fmt.Sprintf("invalid value %q", value)
+		}
+	case key == "Via":
+		_, params, _ := strings.Cut(value, ";")
+
+		if header.UnmarshalVia(value, new(header.Via)) != nil {
+			reason = fmt.Sprintf("invalid value %q", value)
+		} else if !hasValidParams(params) {
+			reason = fmt.Sprintf("invalid parameters in %q", value)
+		}
+	case key == "Contact" && value == "*":
+	case nameAddrHeaders[key]:
+		if header.UnmarshalNameAddr(value, new(header.NameAddr)) != nil {
+			reason = fmt.Sprintf("invalid value %q", value)
+		} else if !hasValidParams(nameAddrParams(value)) {
+			reason = fmt.Sprintf("invalid parameters in %q", value)
+		}
+	default:
+		if empty, ok := tokenListHeaders[key]; ok && !(value == "" && empty) && !grammar.IsToken(value) {
+			reason = fmt.Sprintf("invalid token %q", value)
+		}
+	}
+
+	if reason != "" {
+		return &ParseError{Reason: reason, Err: ErrInvalidSipMessage}
+	}
+
+	return nil
+}
+
+// isText reports whether value is UTF-8 text without control characters other than tabs, as required of header
+// values and reason phrases by RFC 3261 section 25.1.
+func isText(value string) bool {
+	if !utf8.ValidString(value) {
+		return false
+	}
+
+	for index := 0; index < len(value); index++ {
+		if char := value[index]; char < 0x20 && char != '\t' || char == 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isURIText reports whether value only has the characters of an absoluteURI, as defined in RFC 2396, with
+// well-formed escapes.
+func isURIText(value string) bool {
+	for index := 0; index < len(value); index++ {
+		char := value[index]
+
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		case strings.IndexByte(";/?:@&=+$,-_.!~*'()[]", char) >= 0:
+		case char == '%' && index+2 < len(value) && grammar.IsHex(value[index+1]) && grammar.IsHex(value[index+2]):
+			index += 2
+		default:
+			return false
+		}
+	}
+
+	return value != ""
+}
+
+// hasValidQuotes reports whether value has no control characters other than tabs, except when escaped by a
+// quoted-pair, and whether its quoted strings are terminated, as defined in RFC 3261 section 25.1.
+func hasValidQuotes(value string) bool {
+	quoted := false
+
+	for index := 0; index < len(value); index++ {
+		char := value[index]
+
+		switch {
+		case char < 0x20 && char != '\t' || char == 0x7f:
+			return false
+		case char == '"':
+			quoted = !quoted
+		case quoted && char == '\\':
+			// A quoted-pair may escape any ASCII octet but CR and LF
+			if index+1 == len(value) || value[index+1] == '\r' || value[index+1] == '\n' || value[index+1] > 0x7f {
+				return false
+			}
+
+			index++
+		}
+	}
+
+	return !quoted
+}
+
+// isCallID reports whether value is a Call-ID, that is a word optionally followed by "@" and another word.
+func isCallID(value string) bool {
+	id, host, found := strings.Cut(value, "@")
+
+	return isWord(id) && (!found || isWord(host))
+}
+
+// isWord reports whether value is a word, as defined in RFC 3261 section 25.1.
+func isWord(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for index := 0; index < len(value); index++ {
+		if char := value[index]; !grammar.IsTokenChar(char) && strings.IndexByte("()<>:\\\"/[]?{}", char) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isMediaType reports whether value is a media type, or a media range, with optional parameters, such as
+// "application/sdp" or "text/*;q=0.5".
+func isMediaType(value string) bool {
+	media, params, _ := strings.Cut(value, ";")
+	kind, subtype, found := strings.Cut(strings.TrimSpace(media), "/")
+
+	return found && grammar.IsToken(strings.TrimSpace(kind)) && grammar.IsToken(strings.TrimSpace(subtype)) && hasValidParams(params)
+}
+
+// nameAddrParams returns the header parameters of a name-addr or addr-spec value.
+func nameAddrParams(value string) string {
+	quoted := false
+
+	for index := 0; index < len(value); index++ {
+		switch char := value[index]; {
+		case quoted && char == '\\':
+			index++
+		case char == '"':
+			quoted = !quoted
+		case quoted:
+		case char == '<':
+			if end := strings.IndexByte(value[index:], '>'); end >= 0 {
+				_, params, _ := strings.Cut(value[index+end:], ";")
+
+				return params
+			}
+
+			return ""
+		case char == ';':
+			return value[index+1:]
+		}
+	}
+
+	return ""
+}
+
+// hasValidParams reports whether params, the text after the first semicolon of a header value, is a list of
+// generic-params separated by semicolons, where each name is a token and each value is a token, a host or a quoted
+// string.
+func hasValidParams(params string) bool {
+	if strings.TrimSpace(params) == "" {
+		return true
+	}
+
+	parts, _ := grammar.Split(params, ';')
+
+	for _, param := range parts {
+		name, value, found := strings.Cut(param, "=")
+
+		if !grammar.IsToken(strings.TrimSpace(name)) {
+			return false
+		}
+
+		if !found {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch {
+		case grammar.IsToken(value):
+		case strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) && len(value) > 1:
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") && net.ParseIP(value[1:len(value)-1]) != nil:
+		case net.ParseIP(value) != nil:
+			// RFC 5118 section 4.5 allows the received parameter to hold an IPv6 address without brackets
+		default:
+			return false
+		}
+	}
+
+	return true
+}
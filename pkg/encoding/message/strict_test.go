@@ -0,0 +1,106 @@
+package message
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalWithStrictParsingAcceptsValidTortureMessages(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*", "valid", "*.dat"))
+
+	assert.Nil(t, err)
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			payload, err := os.ReadFile(path)
+
+			assert.Nil(t, err)
+
+			want := new(Message)
+
+			assert.Nil(t, Unmarshal(payload, want))
+
+			got := new(Message)
+
+			assert.Nil(t, Unmarshal(payload, got, WithStrictParsing()))
+
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestUnmarshalWithStrictParsing(t *testing.T) {
+	request := "OPTIONS sip:bob@biloxi.com SIP/2.0\r\n"
+
+	table := []struct {
+		name   string
+		input  string
+		header string
+	}{
+		{name: "reason phrase with a control character", input: "SIP/2.0 200 O\x01K\r\n\r\n"},
+		{name: "reason phrase that is not UTF-8", input: "SIP/2.0 200 \xffOK\r\n\r\n"},
+		{name: "Request-URI with a bad escape", input: "OPTIONS mailto:bob%zz@biloxi.com SIP/2.0\r\n\r\n"},
+		{name: "header value that is not UTF-8", input: request + "Subject: \xc3\x28\r\n\r\n", header: "Subject"},
+		{name: "control character", input: request + "Subject: Hello\x07World\r\n\r\n", header: "Subject"},
+		{name: "unterminated quoted string", input: request + "Subject: \"Hello\r\n\r\n", header: "Subject"},
+		{name: "escaped line feed", input: request + "Subject: \"Hello\\\n\"\r\n\r\n", header: "Subject"},
+		{name: "Call-ID with two hosts", input: request + "Call-ID: a84b4c76e66710@biloxi.com@atlanta.com\r\n\r\n", header: "Call-ID"},
+		{name: "Call-ID with a comma", input: request + "Call-ID: a84b4c76e66710,biloxi.com\r\n\r\n", header: "Call-ID"},
+		{name: "Content-Type without subtype", input: request + "Content-Type: application\r\n\r\n", header: "Content-Type"},
+		{name: "Content-Type with a bad parameter", input: request + "Content-Type: text/plain;charset=<utf-8>\r\n\r\n", header: "Content-Type"},
+		{name: "Accept with a space in a type", input: request + "Accept: application/sdp, text/plain html\r\n\r\n", header: "Accept"},
+		{name: "Event with a bad name", input: request + "Event: presence/winfo\r\n\r\n", header: "Event"},
+		{name: "Supported with a bad option tag", input: request + "Supported: 100rel, time@r\r\n\r\n", header: "Supported"},
+		{name: "Require without option tags", input: request + "Require:\r\n\r\n", header: "Require"},
+		{name: "request line with two spaces", input: "OPTIONS  sip:bob@biloxi.com SIP/2.0\r\n\r\n"},
+		{name: "Date in local time", input: request + "Date: Sat, 13 Nov 2010 23:29:00 EST\r\n\r\n", header: "Date"},
+		{name: "repeated Expires", input: request + "Expires: 60\r\nExpires: 3600\r\n\r\n", header: "Expires"},
+		{name: "repeated Content-Type", input: request + "Content-Type: text/plain\r\nc: text/html\r\n\r\n", header: "Content-Type"},
+		{name: "Via without a host", input: request + "Via: SIP/2.0/UDP\r\n\r\n", header: "Via"},
+		{name: "To with an unquoted comma", input: request + "To: Bob, Jr. <sip:bob@biloxi.com>\r\n\r\n", header: "To"},
+		{
+			name:   "Via with a bad parameter name",
+			input:  request + "Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776;@=1\r\n\r\n",
+			header: "Via",
+		},
+		{
+			name:   "From with a bad parameter value",
+			input:  request + "From: <sip:alice@atlanta.com>;tag=19283{01774}\r\n\r\n",
+			header: "From",
+		},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			var got *ParseError
+
+			assert.Nil(t, Unmarshal([]byte(tc.input), new(Message)), "the default parsing accepts it")
+
+			err := Unmarshal([]byte(tc.input), new(Message), WithStrictParsing())
+
+			assert.ErrorIs(t, err, ErrInvalidSipMessage)
+
+			assert.True(t, errors.As(err, &got))
+
+			assert.Equal(t, tc.header, got.Header)
+		})
+	}
+}
+
+func TestUnmarshalWithStrictParsingOfOptionalValues(t *testing.T) {
+	input := "OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+		"Allow:\r\n" +
+		"Supported:\r\n" +
+		"Accept:\r\n" +
+		"Contact: *\r\n" +
+		"Via: SIP/2.0/UDP [2001:db8::9:1];received=[2001:db8::9:255];branch=z9hG4bKas3;rport\r\n" +
+		"To: \"Bob \\\"the builder\\\"\" <sip:bob@biloxi.com>;x=\"a;b\"\r\n" +
+		"Event: presence.winfo;id=1\r\n" +
+		"\r\n"
+
+	assert.Nil(t, Unmarshal([]byte(input), new(Message), WithStrictParsing()))
+}
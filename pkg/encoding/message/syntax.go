@@ -0,0 +1,149 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/internal/grammar"
+	"github.com/otoru/party/pkg/encoding/uri"
+)
+
+// singleHeaders holds the headers that frame a message or identify its transaction and dialog, and so must not
+// appear more than once in a message.
+var singleHeaders = map[string]bool{
+	"Call-ID":        true,
+	"Content-Length": true,
+	"CSeq":           true,
+	"From":           true,
+	"Max-Forwards":   true,
+	"To":             true,
+}
+
+// unmarshalStartLine parses the request line or status line of a message, as defined in RFC 3261 section 7.1 and
+// 7.2, into its typed request or status line and its metadata.
+func unmarshalStartLine(start string, message *Message) error {
+	if strings.HasPrefix(start, "SIP/") {
+		fields := strings.SplitN(start, " ", 3)
+
+		if len(fields) != 3 {
+			return &ParseError{Line: 1, Reason: "malformed status line", Err: ErrInvalidSipMessage}
+		}
+
+		if fields[0] != SIPVersion {
+			return &ParseError{Line: 1, Reason: fmt.Sprintf("unsupported version %q", fields[0]), Err: ErrInvalidSipMessage}
+		}
+
+		code, err := parseStatusCode(fields[1])
+
+		if err != nil {
+			return &ParseError{Line: 1, Reason: fmt.Sprintf("invalid status code %q", fields[1]), Err: ErrInvalidSipMessage}
+		}
+
+		message.Kind = Response
+		message.Status = &StatusLine{Version: fields[0], Code: code, Reason: fields[2]}
+		message.Metadata["version"] = fields[0]
+		message.Metadata["code"] = fields[1]
+		message.Metadata["reason"] = fields[2]
+
+		return nil
+	}
+
+	fields := strings.Fields(start)
+
+	if len(fields) != 3 {
+		return &ParseError{Line: 1, Reason: "malformed request line", Err: ErrInvalidSipMessage}
+	}
+
+	if !grammar.IsToken(fields[0]) {
+		return &ParseError{Line: 1, Reason: fmt.Sprintf("invalid method %q", fields[0]), Err: ErrInvalidSipMessage}
+	}
+
+	target, err := unmarshalRequestURI(fields[1])
+
+	if err != nil {
+		return &ParseError{Line: 1, Reason: err.Error(), Err: ErrInvalidSipMessage}
+	}
+
+	if fields[2] != SIPVersion {
+		return &ParseError{Line: 1, Reason: fmt.Sprintf("unsupported version %q", fields[2]), Err: ErrInvalidSipMessage}
+	}
+
+	message.Kind = Request
+
+	// Request-URIs of other schemes do not fit the typed request line, so they are only kept in the metadata
+	if target != nil {
+		message.Request = &RequestLine{Method: Method(fields[0]), URI: target, Version: fields[2]}
+	}
+
+	message.Metadata["method"] = fields[0]
+	message.Metadata["uri"] = fields[1]
+	message.Metadata["version"] = fields[2]
+
+	return nil
+}
+
+// unmarshalRequestURI parses the Request-URI of a request. SIP and SIPS URIs must be valid and, as required by RFC
+// 3261 section 19.1.5, have no headers. URIs of other schemes are only checked for a scheme and angle brackets, and
+// are returned as nil.
+func unmarshalRequestURI(value string) (*uri.URI, error) {
+	scheme, _, found := strings.Cut(value, ":")
+
+	if !found || strings.ContainsAny(value, "<>\"") {
+		return nil, fmt.Errorf("invalid Request-URI %q", value)
+	}
+
+	if !strings.EqualFold(scheme, "sip") && !strings.EqualFold(scheme, "sips") {
+		return nil, nil
+	}
+
+	target := new(uri.URI)
+
+	if err := uri.Unmarshal(value, target); err != nil {
+		return nil, fmt.Errorf("invalid Request-URI %q", value)
+	}
+
+	if len(target.Headers) > 0 {
+		return nil, fmt.Errorf("headers are not allowed in the Request-URI %q", value)
+	}
+
+	return target, nil
+}
+
+// checkHeader checks a single value of the header key before it is added to message, returning a *ParseError
+// without location if the value is invalid or the header cannot be repeated.
+func checkHeader(message *Message, key string, value string) error {
+	if singleHeaders[key] && message.Headers.Has(key) {
+		return &ParseError{Reason: "header must not be repeated", Err: ErrInvalidSipMessage}
+	}
+
+	single := Headers{key: {value}}
+
+	var err error
+
+	switch {
+	case key == "CSeq":
+		var cseq *CSeq
+
+		if cseq, err = single.CSeq(); err == nil && message.Kind == Request && cseq.Method != message.Method() {
+			return &ParseError{Reason: fmt.Sprintf("method %q does not match the request line", cseq.Method), Err: ErrInvalidSipMessage}
+		}
+	case key == "Call-ID":
+		_, err = single.CallID()
+	case key == "Content-Length":
+		_, err = single.ContentLength()
+	case key == "Max-Forwards":
+		_, err = single.MaxForwards()
+	case key == "Expires":
+		_, err = single.Expires()
+	case key == "Min-Expires":
+		_, err = single.MinExpires()
+	case key == "Retry-After":
+		_, err = single.RetryAfter()
+	}
+
+	if err != nil {
+		return &ParseError{Reason: fmt.Sprintf("invalid value %q", value), Err: ErrInvalidSipMessage}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package message
+
+import (
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/internal/grammar"
+)
+
+// listHeaders holds, by canonical name, the headers whose values are comma-separated lists as defined in
+// RFC 3261 section 7.3.1, including the list headers of widely deployed extensions.
+//
+// Any other header, such as From, To, Date or the authentication headers, is kept whole.
+var listHeaders = map[string]bool{
+	"Accept":               true,
+	"Accept-Contact":       true,
+	"Accept-Encoding":      true,
+	"Accept-Language":      true,
+	"Alert-Info":           true,
+	"Allow":                true,
+	"Allow-Events":         true,
+	"Call-Info":            true,
+	"Contact":              true,
+	"Content-Encoding":     true,
+	"Content-Language":     true,
+	"Error-Info":           true,
+	"History-Info":         true,
+	"In-Reply-To":          true,
+	"P-Asserted-Identity":  true,
+	"P-Preferred-Identity": true,
+	"Path":                 true,
+	"Proxy-Require":        true,
+	"Reason":               true,
+	"Record-Route":         true,
+	"Reject-Contact":       true,
+	"Request-Disposition":  true,
+	"Require":              true,
+	"Route":                true,
+	"Security-Client":      true,
+	"Security-Server":      true,
+	"Security-Verify":      true,
+	"Service-Route":        true,
+	"Supported":            true,
+	"Unsupported":          true,
+	"Via":                  true,
+	"Warning":              true,
+}
+
+// splitHeaderValue splits the value of the header key into its elements.
+//
+// Commas inside quoted strings and angle brackets are not treated as separators, and empty elements are discarded.
+// An unterminated quoted string or angle bracket makes the value invalid.
+// Values of headers that are not comma-separated lists are returned whole.
+func splitHeaderValue(key, value string) ([]string, error) {
+	if !listHeaders[CanonicalHeaderKey(key)] {
+		return []string{value}, nil
+	}
+
+	parts, err := grammar.Split(value, ',')
+
+	if err != nil {
+		return nil, &ParseError{Reason: err.Error(), Err: ErrInvalidSipMessage}
+	}
+
+	var values []string
+
+	for _, part := range parts {
+		if element := strings.TrimSpace(part); element != "" {
+			values = append(values, element)
+		}
+	}
+
+	if len(values) == 0 {
+		return []string{""}, nil
+	}
+
+	return values, nil
+}
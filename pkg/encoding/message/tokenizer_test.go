@@ -0,0 +1,98 @@
+package message
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitHeaderValueWithValidValues(t *testing.T) {
+	table := []struct {
+		key   string
+		value string
+		want  []string
+	}{
+		{
+			key:   "Contact",
+			value: `"Doe, John" <sip:j@example.com>, <sip:k@example.com;expires=60>`,
+			want:  []string{`"Doe, John" <sip:j@example.com>`, "<sip:k@example.com;expires=60>"},
+		},
+		{
+			key:   "Route",
+			value: "<sip:proxy.atlanta.com;lr>,<sip:proxy2.atlanta.com;lr>",
+			want:  []string{"<sip:proxy.atlanta.com;lr>", "<sip:proxy2.atlanta.com;lr>"},
+		},
+		{
+			key:   "m",
+			value: `"Quoted \", comma" <sip:a@example.com?Subject=a,b>`,
+			want:  []string{`"Quoted \", comma" <sip:a@example.com?Subject=a,b>`},
+		},
+		{
+			key:   "Warning",
+			value: `370 devnull "Choose a bigger pipe", 307 isi.edu "Session parameter 'foo' not understood"`,
+			want:  []string{`370 devnull "Choose a bigger pipe"`, `307 isi.edu "Session parameter 'foo' not understood"`},
+		},
+		{
+			key:   "via",
+			value: "SIP/2.0/UDP 192.168.0.2:5060;branch=z9hG4bK2,",
+			want:  []string{"SIP/2.0/UDP 192.168.0.2:5060;branch=z9hG4bK2"},
+		},
+		{
+			key:   "Supported",
+			value: "",
+			want:  []string{""},
+		},
+		{
+			key:   "From",
+			value: `"Doe, John" <sip:j@example.com>;tag=1234`,
+			want:  []string{`"Doe, John" <sip:j@example.com>;tag=1234`},
+		},
+		{
+			key:   "Date",
+			value: "Sat, 13 Nov 2010 23:29:00 GMT",
+			want:  []string{"Sat, 13 Nov 2010 23:29:00 GMT"},
+		},
+		{
+			key:   "WWW-Authenticate",
+			value: `Digest realm="atlanta.com", domain="sip:boxesbybob.com", qop="auth", nonce="f84f1cec41e6cbe5aea9c8e88d359", opaque="", stale=FALSE, algorithm=MD5`,
+			want:  []string{`Digest realm="atlanta.com", domain="sip:boxesbybob.com", qop="auth", nonce="f84f1cec41e6cbe5aea9c8e88d359", opaque="", stale=FALSE, algorithm=MD5`},
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			got, err := splitHeaderValue(test.key, test.value)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSplitHeaderValueWithInvalidValues(t *testing.T) {
+	table := []struct {
+		key   string
+		value string
+	}{
+		{
+			key:   "Contact",
+			value: `"Doe, John <sip:j@example.com>`,
+		},
+		{
+			key:   "Route",
+			value: "<sip:proxy.atlanta.com;lr, <sip:proxy2.atlanta.com;lr>",
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			got, err := splitHeaderValue(test.key, test.value)
+
+			assert.Nil(t, got)
+
+			assert.ErrorIs(t, err, ErrInvalidSipMessage)
+		})
+	}
+}
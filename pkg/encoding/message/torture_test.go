@@ -0,0 +1,120 @@
+package message
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The testdata directory holds the torture messages of RFC 4475 and the IPv6 torture messages of RFC 5118, named
+// after the tests of those documents. Messages that a parser must accept are under valid, including the ones with
+// transaction or application layer problems, and messages that a parser must reject are under invalid.
+
+func TestUnmarshalAcceptsValidTortureMessages(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*", "valid", "*.dat"))
+
+	assert.Nil(t, err)
+
+	assert.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			payload, err := os.ReadFile(path)
+
+			assert.Nil(t, err)
+
+			got := new(Message)
+
+			assert.Nil(t, Unmarshal(payload, got))
+
+			encoded, err := Marshal(got)
+
+			assert.Nil(t, err)
+
+			again := new(Message)
+
+			assert.Nil(t, Unmarshal(encoded, again))
+
+			assert.Equal(t, got.Kind, again.Kind)
+
+			assert.Equal(t, got.Metadata, again.Metadata)
+
+			assert.Equal(t, got.Body, again.Body)
+
+			// Marshal always writes the Content-Length header, even for messages received without one
+			got.Headers.SetContentLength(len(got.Body))
+
+			assert.Equal(t, got.Headers, again.Headers)
+		})
+	}
+}
+
+func TestUnmarshalRejectsInvalidTortureMessages(t *testing.T) {
+	// Messages marked as strict are only rejected by WithStrictParsing, since their problem is not in the framing of
+	// the message nor in the values the default parser reads.
+	table := []struct {
+		name   string
+		header string
+		strict bool
+		err    error
+	}{
+		{name: "rfc4475/invalid/badaspec.dat", header: "To", strict: true, err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/baddate.dat", header: "Date", strict: true, err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/baddn.dat", header: "From", strict: true, err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/badinv01.dat", header: "Via", strict: true, err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/badvers.dat", header: "", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/bigcode.dat", header: "", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/clerr.dat", header: "Content-Length", err: ErrInvalidBodyOnSIPMessage},
+		{name: "rfc4475/invalid/escruri.dat", header: "", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/ltgtruri.dat", header: "", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/lwsruri.dat", header: "", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/lwsstart.dat", header: "", strict: true, err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/mcl01.dat", header: "Content-Length", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/mismatch01.dat", header: "CSeq", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/mismatch02.dat", header: "CSeq", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/multi01.dat", header: "CSeq", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/ncl.dat", header: "Content-Length", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/quotbal.dat", header: "To", strict: true, err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/regbadct.dat", header: "Contact", strict: true, err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/scalar02.dat", header: "CSeq", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/scalarlg.dat", header: "CSeq", err: ErrInvalidSipMessage},
+		{name: "rfc4475/invalid/trws.dat", header: "", strict: true, err: ErrInvalidSipMessage},
+		{name: "rfc5118/invalid/ipv6-bad.dat", header: "", err: ErrInvalidSipMessage},
+		{name: "rfc5118/invalid/ipv6-bug-abnf-3-colons.dat", header: "", err: ErrInvalidSipMessage},
+	}
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "*", "invalid", "*.dat"))
+
+	assert.Nil(t, err)
+
+	assert.Len(t, paths, len(table), "every invalid message must have an expected error")
+
+	for _, test := range table {
+		t.Run(test.name, func(t *testing.T) {
+			payload, err := os.ReadFile(filepath.Join("testdata", filepath.FromSlash(test.name)))
+
+			assert.Nil(t, err)
+
+			var parseError *ParseError
+
+			var options []UnmarshalOption
+
+			if test.strict {
+				assert.Nil(t, Unmarshal(payload, new(Message)))
+
+				options = append(options, WithStrictParsing())
+			}
+
+			err = Unmarshal(payload, new(Message), options...)
+
+			assert.ErrorIs(t, err, test.err)
+
+			if assert.True(t, errors.As(err, &parseError)) {
+				assert.Equal(t, test.header, parseError.Header)
+			}
+		})
+	}
+}
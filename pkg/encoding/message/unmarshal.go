@@ -2,49 +2,93 @@ package message
 
 import (
 	"bytes"
-	"encoding/base64"
+	"errors"
+	"fmt"
 	"strings"
+
+	"github.com/otoru/party/pkg/encoding/internal/grammar"
 )
 
+// headerLine is a header found in the payload, with its continuation lines already folded into its value.
+type headerLine struct {
+	key    string
+	value  string
+	line   int
+	offset int
+}
+
 // Unmarshal parses the SIP-encoded data and stores the result in the value pointed to by message.
-func Unmarshal(payload []byte, message *Message) error {
+//
+// The body is stored as is, truncated to the length declared in the Content-Length header if there is one.
+// The start line, the framing of the message and the values read by the accessors, such as CSeq, Call-ID and
+// Content-Length, are checked against RFC 3261, and the headers that identify a message must not be repeated.
+// Parsing failures are reported as a *ParseError that wraps ErrInvalidSipMessage or ErrInvalidBodyOnSIPMessage.
+//
+// Options, such as WithSipfragParsing, WithLenientParsing and WithStrictParsing, change how the payload is parsed.
+// The DefaultLimits are enforced unless WithLimits is given.
+func Unmarshal(payload []byte, message *Message, options ...UnmarshalOption) error {
+	settings := newUnmarshalOptions(options)
+
 	message.Metadata = make(map[string]string)
+	message.Request = nil
+	message.Status = nil
 	message.Headers = make(map[string][]string)
+	message.Order = nil
+	message.Body = nil
+	message.Warnings = nil
+
+	limits := settings.limits
+
+	if exceeds(len(payload), limits.MessageSize) {
+		return overLimit(ErrMessageTooLarge, "message size", len(payload), limits.MessageSize)
+	}
+
+	if settings.mode == lenientParsing {
+		payload, message.Warnings = repair(payload, settings.sipfrag)
+	}
 
 	lines := bytes.Split(payload, CRLF)
 	first, lines := lines[0], lines[1:]
 
-	start := string(first)
-	fields := strings.Fields(start)
+	if exceeds(len(first), limits.HeaderLineLength) {
+		return locate(overLimit(ErrHeaderLineTooLong, "line length", len(first), limits.HeaderLineLength), 1, 0, "")
+	}
 
-	if len(fields) < 3 {
-		return ErrInvalidSipMessage
+	if err := unmarshalStartLine(string(first), message); err != nil {
+		return err
 	}
 
-	if strings.HasPrefix(start, "SIP/") {
-		message.Kind = Response
-		message.Metadata["version"] = fields[0]
-		message.Metadata["code"] = fields[1]
-		message.Metadata["reason"] = strings.Join(fields[2:], " ")
-	} else if len(fields) != 3 {
-		return ErrInvalidSipMessage
-	} else {
-		message.Kind = Request
-		message.Metadata["method"] = fields[0]
-		message.Metadata["uri"] = fields[1]
-		message.Metadata["version"] = fields[2]
+	if settings.mode == strictParsing {
+		if err := checkStrictStartLine(string(first), message); err != nil {
+			return err
+		}
 	}
 
-	var key string
-	var value string
+	var parsed []headerLine
+
+	var body []byte
 
 	var blankLine bool
 	var end bool
 
+	// number and offset locate the current line in the payload, starting after the start line
+	number := 1
+	offset := len(first) + len(CRLF)
+
 loop:
 	for index, line := range lines {
 		line := string(line)
 
+		number++
+
+		if index > 0 {
+			offset += len(lines[index-1]) + len(CRLF)
+		}
+
+		if exceeds(len(line), limits.HeaderLineLength) {
+			return locate(overLimit(ErrHeaderLineTooLong, "line length", len(line), limits.HeaderLineLength), number, offset, "")
+		}
+
 		switch {
 		case len(line) == 0:
 			// We found the blank line
@@ -53,9 +97,7 @@ loop:
 				end = true
 			} else {
 				// The blank line separates the headers and the body of the SIP message
-				body := bytes.Join(lines[index+1:], CRLF)
-				content := base64.StdEncoding.EncodeToString(body)
-				message.Body = content
+				body = bytes.Join(lines[index+1:], CRLF)
 			}
 
 			blankLine = true
@@ -65,37 +107,133 @@ loop:
 		case line[0] == ' ' || line[0] == '\t':
 			// We found a header with multi-line-value
 
-			if key == "" {
-				return ErrInvalidSipMessage
+			if len(parsed) == 0 {
+				return &ParseError{Line: number, Offset: offset, Reason: "continuation line without header", Err: ErrInvalidSipMessage}
 			}
 
-			value = strings.TrimSpace(line)
+			// As defined in RFC 3261 section 7.3.1, the folding is replaced by a single space
+			last := &parsed[len(parsed)-1]
+			last.value = strings.TrimSpace(last.value + " " + strings.TrimSpace(line))
 
 		default:
 			// We found a new sip header
-			fields := strings.SplitN(line, ":", 2)
+			name, value, found := strings.Cut(line, ":")
+
+			if !found {
+				return &ParseError{Line: number, Offset: offset, Reason: "missing colon after header name", Err: ErrInvalidSipMessage}
+			}
+
+			if !grammar.IsToken(strings.TrimSpace(name)) {
+				return &ParseError{Line: number, Offset: offset, Reason: fmt.Sprintf("invalid header name %q", name), Err: ErrInvalidSipMessage}
+			}
 
-			if len(fields) != 2 {
-				return ErrInvalidSipMessage
+			if exceeds(len(parsed)+1, limits.Headers) {
+				return locate(overLimit(ErrTooManyHeaders, "header count", len(parsed)+1, limits.Headers), number, offset, "")
 			}
 
-			key = strings.TrimSpace(fields[0])
-			value = strings.TrimSpace(fields[1])
-			value = strings.TrimSuffix(value, ",")
+			parsed = append(parsed, headerLine{
+				key:    CanonicalHeaderKey(name),
+				value:  strings.TrimSpace(value),
+				line:   number,
+				offset: offset,
+			})
 		}
+	}
 
-		values := strings.Split(value, ",")
+	for _, field := range parsed {
+		// Known names are stored under their canonical key, so only the spelling of the others needs looking up
+		if _, known := knownNames[field.key]; !known {
+			if key, ok := message.Headers.key(field.key); ok {
+				field.key = key
+			}
+		}
 
-		if headers, has := message.Headers[key]; has {
-			message.Headers[key] = append(headers, values...)
-		} else {
-			var headers []string
-			message.Headers[key] = append(headers, values...)
+		values, err := splitHeaderValue(field.key, field.value)
+
+		if err != nil {
+			return locate(err, field.line, field.offset, field.key)
 		}
+
+		if count := len(message.Headers[field.key]) + len(values); exceeds(count, limits.ValuesPerHeader) {
+			return locate(overLimit(ErrTooManyHeaderValues, "value count", count, limits.ValuesPerHeader), field.line, field.offset, field.key)
+		}
+
+		for _, value := range values {
+			if err := checkHeader(message, field.key, value); err != nil {
+				return locate(err, field.line, field.offset, field.key)
+			}
+
+			if settings.mode == strictParsing {
+				if err := checkStrictHeader(message, field.key, value); err != nil {
+					return locate(err, field.line, field.offset, field.key)
+				}
+			}
+
+			message.Headers[field.key] = append(message.Headers[field.key], value)
+			message.record(field.key)
+		}
+	}
+
+	// A sipfrag may end right after its start line or its last header
+	if (!blankLine || end) && !settings.sipfrag {
+		return &ParseError{Line: number, Offset: offset, Reason: "missing blank line after headers", Err: ErrInvalidSipMessage}
+	}
+
+	if err := unmarshalBody(body, message, limits.BodySize); err != nil {
+		return locate(err, number+1, len(payload)-len(body), "Content-Length")
+	}
+
+	return nil
+}
+
+// locate sets the line, offset and header of err when it is a *ParseError, or wraps it in a new *ParseError
+// otherwise.
+func locate(err error, line int, offset int, header string) error {
+	var parseError *ParseError
+
+	if !errors.As(err, &parseError) {
+		return &ParseError{Line: line, Offset: offset, Header: header, Err: err}
+	}
+
+	parseError.Line = line
+	parseError.Offset = offset
+	parseError.Header = header
+
+	return parseError
+}
+
+// unmarshalBody stores body in message, checking it against the Content-Length header when there is one.
+//
+// As required by RFC 3261 section 18.3, octets beyond the declared length are discarded, and a body shorter than
+// the declared length makes the message invalid. A body longer than limit, when it is positive, is rejected.
+func unmarshalBody(body []byte, message *Message, limit int) error {
+	if message.Headers.Has("Content-Length") {
+		length, err := message.Headers.ContentLength()
+
+		if err != nil {
+			return &ParseError{Reason: "invalid value", Err: ErrInvalidSipMessage}
+		}
+
+		if exceeds(length, limit) {
+			return overLimit(ErrBodyTooLarge, "body size", length, limit)
+		}
+
+		if length > len(body) {
+			return &ParseError{
+				Reason: fmt.Sprintf("body has %d octets but %d were declared", len(body), length),
+				Err:    ErrInvalidBodyOnSIPMessage,
+			}
+		}
+
+		body = body[:length]
+	}
+
+	if exceeds(len(body), limit) {
+		return overLimit(ErrBodyTooLarge, "body size", len(body), limit)
 	}
 
-	if !blankLine || end {
-		return ErrInvalidSipMessage
+	if len(body) > 0 {
+		message.Body = body
 	}
 
 	return nil
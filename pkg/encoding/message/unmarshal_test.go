@@ -1,6 +1,7 @@
 package message
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -22,7 +23,7 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 				"CSeq: 1 INVITE\r\n" +
 				"Contact: <sip:alice@client.atlanta.example.com>\r\n" +
 				"Content-Type: application/sdp\r\n" +
-				"Content-Length: 142\r\n" +
+				"Content-Length: 151\r\n" +
 				"\r\n" +
 				"v=0\r\n" +
 				"o=alice 2890844526 2890842807 IN IP4 client.atlanta.example.com\r\n" +
@@ -38,6 +39,7 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 					"uri":     "sip:user@example.com",
 					"version": "SIP/2.0",
 				},
+				Request: &RequestLine{Method: MethodInvite, URI: sipURI("sip:user@example.com"), Version: SIPVersion},
 				Headers: Headers{
 					"Via":            {"SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bKnashds7"},
 					"Max-Forwards":   {"70"},
@@ -47,9 +49,16 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 					"CSeq":           {"1 INVITE"},
 					"Contact":        {"<sip:alice@client.atlanta.example.com>"},
 					"Content-Type":   {"application/sdp"},
-					"Content-Length": {"142"},
+					"Content-Length": {"151"},
 				},
-				Body: "dj0wDQpvPWFsaWNlIDI4OTA4NDQ1MjYgMjg5MDg0MjgwNyBJTiBJUDQgY2xpZW50LmF0bGFudGEuZXhhbXBsZS5jb20NCnM9LQ0KYz1JTiBJUDQgMTkyLjAuMi4xMDENCnQ9MCAwDQptPWF1ZGlvIDQ5MTcwIFJUUC9BVlAgMA0KYT1ydHBtYXA6MCBQQ01VLzgwMDANCg==",
+				Order: []string{"Via", "Max-Forwards", "From", "To", "Call-ID", "CSeq", "Contact", "Content-Type", "Content-Length"},
+				Body: []byte("v=0\r\n" +
+					"o=alice 2890844526 2890842807 IN IP4 client.atlanta.example.com\r\n" +
+					"s=-\r\n" +
+					"c=IN IP4 192.0.2.101\r\n" +
+					"t=0 0\r\n" +
+					"m=audio 49170 RTP/AVP 0\r\n" +
+					"a=rtpmap:0 PCMU/8000\r\n"),
 			},
 		},
 		{
@@ -67,6 +76,7 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 					"code":    "200",
 					"reason":  "OK",
 				},
+				Status: &StatusLine{Version: SIPVersion, Code: 200, Reason: "OK"},
 				Headers: Headers{
 					"Via":            {"SIP/2.0/UDP 10.0.0.1:5060;branch=z9hG4bKkjshdyff"},
 					"To":             {"sip:bob@example.com;tag=abc123"},
@@ -75,7 +85,7 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 					"Call-ID":        {"abcdefg1234567890"},
 					"Content-Length": {"0"},
 				},
-				Body: "",
+				Order: []string{"Via", "To", "From", "CSeq", "Call-ID", "Content-Length"},
 			},
 		},
 		{
@@ -90,7 +100,7 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 				"Content-Type: application/sdp\r\n" +
 				"Via: SIP/2.0/TCP pc33.atlanta.com;branch=z9hG4bKnashds8\r\n" +
 				"Via: SIP/2.0/UDP 192.168.1.1;branch=z9hG4bK776asdhds\r\n" +
-				"Content-Length: 131\r\n" +
+				"Content-Length: 118\r\n" +
 				"\r\n" +
 				"v=0\r\n" +
 				"o=- 20518 0 IN IP4 192.168.1.1\r\n" +
@@ -106,11 +116,12 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 					"uri":     "sip:100@10.0.0.1",
 					"version": "SIP/2.0",
 				},
+				Request: &RequestLine{Method: MethodInvite, URI: sipURI("sip:100@10.0.0.1"), Version: SIPVersion},
 				Headers: Headers{
 					"CSeq":           {"314159 INVITE"},
 					"Call-ID":        {"a84b4c76e66710@pc33.atlanta.com"},
 					"Contact":        {"<sip:alice@pc33.atlanta.com>"},
-					"Content-Length": {"131"},
+					"Content-Length": {"118"},
 					"Content-Type":   {"application/sdp"},
 					"From":           {"Alice <sip:alice@atlanta.com>;tag=1928301774"},
 					"Max-Forwards":   {"70"},
@@ -121,7 +132,14 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 						"SIP/2.0/UDP 192.168.1.1;branch=z9hG4bK776asdhds",
 					},
 				},
-				Body: "dj0wDQpvPS0gMjA1MTggMCBJTiBJUDQgMTkyLjE2OC4xLjENCnM9LQ0KYz1JTiBJUDQgMTkyLjE2OC4xLjENCnQ9MCAwDQptPWF1ZGlvIDQ5MTcwIFJUUC9BVlAgMA0KYT1ydHBtYXA6MCBQQ01VLzgwMDANCg==",
+				Order: []string{"Via", "Max-Forwards", "From", "To", "Call-ID", "CSeq", "Contact", "Content-Type", "Via", "Via", "Content-Length"},
+				Body: []byte("v=0\r\n" +
+					"o=- 20518 0 IN IP4 192.168.1.1\r\n" +
+					"s=-\r\n" +
+					"c=IN IP4 192.168.1.1\r\n" +
+					"t=0 0\r\n" +
+					"m=audio 49170 RTP/AVP 0\r\n" +
+					"a=rtpmap:0 PCMU/8000\r\n"),
 			},
 		},
 		{
@@ -136,7 +154,7 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 				"Max-Forwards: 70\r\n" +
 				"Contact: <sip:caller@192.168.0.1>\r\n" +
 				"Content-Type: application/sdp\r\n" +
-				"Content-Length: 150\r\n" +
+				"Content-Length: 134\r\n" +
 				"\r\n" +
 				"v=0\r\n" +
 				"o=user1 53655765 2353687637 IN IP4 192.168.0.1\r\n" +
@@ -152,11 +170,12 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 					"uri":     "sip:sips@example.com",
 					"version": "SIP/2.0",
 				},
+				Request: &RequestLine{Method: MethodInvite, URI: sipURI("sip:sips@example.com"), Version: SIPVersion},
 				Headers: Headers{
 					"CSeq":           {"1 INVITE"},
 					"Call-ID":        {"1234567890@example.com"},
 					"Contact":        {"<sip:caller@192.168.0.1>"},
-					"Content-Length": {"150"},
+					"Content-Length": {"134"},
 					"Content-Type":   {"application/sdp"},
 					"From":           {"<sip:caller@example.com>;tag=12345"},
 					"Max-Forwards":   {"70"},
@@ -167,7 +186,14 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 						"SIP/2.0/UDP 192.168.0.3:5060;branch=z9hG4bK3",
 					},
 				},
-				Body: "dj0wDQpvPXVzZXIxIDUzNjU1NzY1IDIzNTM2ODc2MzcgSU4gSVA0IDE5Mi4xNjguMC4xDQpzPS0NCmM9SU4gSVA0IDE5Mi4xNjguMC4xDQp0PTAgMA0KbT1hdWRpbyA0OTE3MCBSVFAvQVZQIDANCmE9cnRwbWFwOjAgUENNVS84MDAwDQo=",
+				Order: []string{"Via", "Via", "Via", "From", "To", "Call-ID", "CSeq", "Max-Forwards", "Contact", "Content-Type", "Content-Length"},
+				Body: []byte("v=0\r\n" +
+					"o=user1 53655765 2353687637 IN IP4 192.168.0.1\r\n" +
+					"s=-\r\n" +
+					"c=IN IP4 192.168.0.1\r\n" +
+					"t=0 0\r\n" +
+					"m=audio 49170 RTP/AVP 0\r\n" +
+					"a=rtpmap:0 PCMU/8000\r\n"),
 			},
 		},
 		{
@@ -196,6 +222,7 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 					"uri":     "sip:bob@biloxi.com",
 					"version": "SIP/2.0",
 				},
+				Request: &RequestLine{Method: MethodInvite, URI: sipURI("sip:bob@biloxi.com"), Version: SIPVersion},
 				Headers: Headers{
 					"Via":            {"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds"},
 					"Max-Forwards":   {"70"},
@@ -208,7 +235,76 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 					"Content-Type":   {"application/sdp"},
 					"Route":          {"<sip:proxy.atlanta.com;lr>", "<sip:proxy2.atlanta.com;lr>"},
 				},
-				Body: "dj0wDQpvPS0gMTM4NjA1MjgzNCAxMzg2MDUyODM0IElOIElQNCAxMjcuMC4wLjENCnM9VGVzdCBTZXNzaW9uDQpjPUlOIElQNCAxOTIuMTY4LjAuMTAwDQp0PTAgMA0KbT1hdWRpbyA0MDAwIFJUUC9BVlAgMA0KYT1ydHBtYXA6MCBQQ01VLzgwMDANCg==",
+				Order: []string{"Via", "Max-Forwards", "To", "From", "Call-ID", "CSeq", "Contact", "Route", "Route", "Content-Type", "Content-Length"},
+				Body: []byte("v=0\r\n" +
+					"o=- 1386052834 1386052834 IN IP4 127.0.0.1\r\n" +
+					"s=Test Session\r\n" +
+					"c=IN IP4 192.168.0.100\r\n" +
+					"t=0 0\r\n" +
+					"m=audio 4000 RTP/AVP 0\r\n" +
+					"a=rtpmap:0 PCMU/8000\r\n"),
+			},
+		},
+		{
+			input: []byte("SIP/2.0 401 Unauthorized\r\n" +
+				"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+				"To: \"Doe, John\" <sip:john@biloxi.com>;tag=a6c85cf\r\n" +
+				"From: \"Roe, Jane\" <sip:jane@atlanta.com>;tag=1928301774\r\n" +
+				"Call-ID: a84b4c76e66710\r\n" +
+				"CSeq: 1 REGISTER\r\n" +
+				"Date: Sat, 13 Nov 2010 23:29:00 GMT\r\n" +
+				"Contact: \"Doe, John\" <sip:john@192.0.2.4>, <sip:john@192.0.2.5;transport=tcp>\r\n" +
+				"WWW-Authenticate: Digest realm=\"atlanta.com\", qop=\"auth\", nonce=\"84a4cc6f3082121f32b42a2187831a9e\"\r\n" +
+				"Content-Length: 0\r\n\r\n"),
+			want: &Message{
+				Kind: Response,
+				Metadata: Metadata{
+					"version": "SIP/2.0",
+					"code":    "401",
+					"reason":  "Unauthorized",
+				},
+				Status: &StatusLine{Version: SIPVersion, Code: 401, Reason: "Unauthorized"},
+				Headers: Headers{
+					"Via":              {"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds"},
+					"To":               {"\"Doe, John\" <sip:john@biloxi.com>;tag=a6c85cf"},
+					"From":             {"\"Roe, Jane\" <sip:jane@atlanta.com>;tag=1928301774"},
+					"Call-ID":          {"a84b4c76e66710"},
+					"CSeq":             {"1 REGISTER"},
+					"Date":             {"Sat, 13 Nov 2010 23:29:00 GMT"},
+					"Contact":          {"\"Doe, John\" <sip:john@192.0.2.4>", "<sip:john@192.0.2.5;transport=tcp>"},
+					"WWW-Authenticate": {"Digest realm=\"atlanta.com\", qop=\"auth\", nonce=\"84a4cc6f3082121f32b42a2187831a9e\""},
+					"Content-Length":   {"0"},
+				},
+				Order: []string{"Via", "To", "From", "Call-ID", "CSeq", "Date", "Contact", "Contact", "WWW-Authenticate", "Content-Length"},
+			},
+		},
+		{
+			input: []byte("BYE sip:bob@192.0.2.4 SIP/2.0\r\n" +
+				"v: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bKnashds10\r\n" +
+				"max-forwards: 70\r\n" +
+				"f: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+				"t: Bob <sip:bob@biloxi.com>;tag=a6c85cf\r\n" +
+				"CALL-ID: a84b4c76e66710\r\n" +
+				"cseq: 231 BYE\r\n" +
+				"l: 0\r\n\r\n"),
+			want: &Message{
+				Kind: Request,
+				Metadata: Metadata{
+					"method":  "BYE",
+					"uri":     "sip:bob@192.0.2.4",
+					"version": "SIP/2.0",
+				},
+				Request: &RequestLine{Method: MethodBye, URI: sipURI("sip:bob@192.0.2.4"), Version: SIPVersion},
+				Headers: Headers{
+					"Via":            {"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bKnashds10"},
+					"Max-Forwards":   {"70"},
+					"From":           {"Alice <sip:alice@atlanta.com>;tag=1928301774"},
+					"To":             {"Bob <sip:bob@biloxi.com>;tag=a6c85cf"},
+					"Call-ID":        {"a84b4c76e66710"},
+					"CSeq":           {"231 BYE"},
+					"Content-Length": {"0"},
+				},
+				Order: []string{"Via", "Max-Forwards", "From", "To", "Call-ID", "CSeq", "Content-Length"},
 			},
 		},
 	}
@@ -225,6 +321,53 @@ func TestUnmarshalWithValidMessages(t *testing.T) {
 	}
 }
 
+func TestUnmarshalTruncatesBodyToContentLength(t *testing.T) {
+	input := []byte("MESSAGE sip:bob@biloxi.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"To: Bob <sip:bob@biloxi.com>\r\n" +
+		"From: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+		"Call-ID: a84b4c76e66710\r\n" +
+		"CSeq: 314159 MESSAGE\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"Content-Length: 18\r\n" +
+		"\r\n" +
+		"Watson, come here.\r\n\r\nTrailing garbage")
+
+	got := new(Message)
+
+	err := Unmarshal(input, got)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, []byte("Watson, come here."), got.Body)
+}
+
+func TestUnmarshalKeepsTheSpellingOfUnknownHeaders(t *testing.T) {
+	input := []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"To: Bob <sip:bob@biloxi.com>\r\n" +
+		"From: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+		"Call-ID: a84b4c76e66710\r\n" +
+		"CSeq: 63104 OPTIONS\r\n" +
+		"X-Tracking-ID: first\r\n" +
+		"x-tracking-id: second\r\n" +
+		"min-se: 90\r\n" +
+		"Content-Length: 0\r\n" +
+		"\r\n")
+
+	got := new(Message)
+
+	err := Unmarshal(input, got)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"first", "second"}, got.Headers["X-Tracking-ID"])
+	assert.Equal(t, []string{"90"}, got.Headers["Min-SE"])
+	assert.NotContains(t, got.Headers, "x-tracking-id")
+}
+
 func TestUnmarshalWithInvalidMessages(t *testing.T) {
 	table := []struct {
 		input []byte
@@ -282,6 +425,34 @@ func TestUnmarshalWithInvalidMessages(t *testing.T) {
 				"a=rtpmap:0 PCMU/8000\r\n"),
 			err: ErrInvalidSipMessage,
 		},
+		{
+			input: []byte("MESSAGE sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+				"Max-Forwards: 70\r\n" +
+				"To: Bob <sip:bob@biloxi.com>\r\n" +
+				"From: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+				"Call-ID: a84b4c76e66710\r\n" +
+				"CSeq: 314159 MESSAGE\r\n" +
+				"Content-Type: text/plain\r\n" +
+				"Content-Length: 50\r\n" +
+				"\r\n" +
+				"Watson, come here."),
+			err: ErrInvalidBodyOnSIPMessage,
+		},
+		{
+			input: []byte("MESSAGE sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+				"Max-Forwards: 70\r\n" +
+				"To: Bob <sip:bob@biloxi.com>\r\n" +
+				"From: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+				"Call-ID: a84b4c76e66710\r\n" +
+				"CSeq: 314159 MESSAGE\r\n" +
+				"Content-Type: text/plain\r\n" +
+				"Content-Length: -18\r\n" +
+				"\r\n" +
+				"Watson, come here."),
+			err: ErrInvalidSipMessage,
+		},
 		{
 			input: []byte("MESSAGE sip:bob@biloxi.com SIP/2.0\r\n" +
 				"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
@@ -328,3 +499,202 @@ func TestUnmarshalWithInvalidMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalReportsWhereParsingFailed(t *testing.T) {
+	table := []struct {
+		input []byte
+		want  *ParseError
+	}{
+		{
+			input: []byte("INVITE sip:bob@biloxi.com\r\n\r\n"),
+			want:  &ParseError{Line: 1, Offset: 0, Reason: "malformed request line", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Call-ID: a84b4c76e66710\r\n" +
+				"Watson come here\r\n" +
+				"\r\n"),
+			want: &ParseError{Line: 3, Offset: 61, Reason: "missing colon after header name", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Call ID: a84b4c76e66710\r\n" +
+				"\r\n"),
+			want: &ParseError{Line: 2, Offset: 36, Reason: "invalid header name \"Call ID\"", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				" SIP/2.0/UDP 192.168.0.3:5060;branch=z9hG4bK3\r\n" +
+				"\r\n"),
+			want: &ParseError{Line: 2, Offset: 36, Reason: "continuation line without header", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Call-ID: a84b4c76e66710\r\n" +
+				"Contact: \"Bob <sip:bob@biloxi.com>\r\n" +
+				"\r\n"),
+			want: &ParseError{Line: 3, Offset: 61, Header: "Contact", Reason: "unterminated quoted string", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Call-ID: a84b4c76e66710\r\n"),
+			want: &ParseError{Line: 3, Offset: 61, Reason: "missing blank line after headers", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"l: 10\r\n" +
+				"\r\n" +
+				"Watson"),
+			want: &ParseError{
+				Line:   4,
+				Offset: 45,
+				Header: "Content-Length",
+				Reason: "body has 6 octets but 10 were declared",
+				Err:    ErrInvalidBodyOnSIPMessage,
+			},
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			var got *ParseError
+
+			err := Unmarshal(test.input, new(Message))
+
+			assert.ErrorIs(t, err, test.want.Err)
+
+			assert.True(t, errors.As(err, &got))
+
+			assert.Equal(t, got, test.want)
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	err := &ParseError{Line: 3, Offset: 61, Header: "Contact", Reason: "unterminated quoted string", Err: ErrInvalidSipMessage}
+
+	assert.Equal(t, "invalid SIP message: line 3 (offset 61): Contact: unterminated quoted string", err.Error())
+}
+
+// wsinv is the "short tortuous INVITE" of RFC 4475 section 3.1.1.1, which folds most of its headers.
+var wsinv = "INVITE sip:vivekg@chair-dnrc.example.com;unknownparam SIP/2.0\r\n" +
+	"TO :\r\n" +
+	" sip:vivekg@chair-dnrc.example.com ;   tag    = 1918181833n\r\n" +
+	"from   : \"J Rosenberg \\\\\\\"\"       <sip:jdrosen@example.com>\r\n" +
+	"  ;\r\n" +
+	"  tag = 98asjd8\r\n" +
+	"MaX-fOrWaRdS: 0068\r\n" +
+	"Call-ID: wsinv.ndaksdj@192.0.2.1\r\n" +
+	"Content-Length   : 150\r\n" +
+	"cseq: 0009\r\n" +
+	"  INVITE\r\n" +
+	"Via  : SIP  /   2.0\r\n" +
+	" /UDP\r\n" +
+	"    192.0.2.2;branch=390skdjuw\r\n" +
+	"s :\r\n" +
+	"NewFangledHeader:   newfangled value\r\n" +
+	" continued newfangled value\r\n" +
+	"UnknownHeaderWithUnusualValue: ;;,,;;,;\r\n" +
+	"Content-Type: application/sdp\r\n" +
+	"Route:\r\n" +
+	" <sip:services.example.com;lr;unknownwith=value;unknown-no-value>\r\n" +
+	"v:  SIP  / 2.0  / TCP     spindle.example.com   ;\r\n" +
+	"  branch  =   z9hG4bK9ikj8  ,\r\n" +
+	" SIP  /    2.0   / UDP  192.168.255.111   ; branch=\r\n" +
+	" z9hG4bK30239\r\n" +
+	"m:\"Quoted string \\\"\\\"\" <sip:jdrosen@example.com> ; newparam =\r\n" +
+	"      newvalue ;\r\n" +
+	"  secondparam ; q = 0.33\r\n" +
+	"\r\n" +
+	"v=0\r\n" +
+	"o=mhandley 29739 7272939 IN IP4 192.0.2.3\r\n" +
+	"s=-\r\n" +
+	"c=IN IP4 192.0.2.4\r\n" +
+	"t=0 0\r\n" +
+	"m=audio 49217 RTP/AVP 0 12\r\n" +
+	"m=video 3227 RTP/AVP 31\r\n" +
+	"a=rtpmap:31 LPC\r\n"
+
+func TestUnmarshalFoldsContinuationLines(t *testing.T) {
+	table := []struct {
+		input []byte
+		want  Headers
+	}{
+		{
+			input: []byte("MESSAGE sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Subject: I know you're there,\r\n" +
+				"         pick up the phone\r\n" +
+				"\t and talk to me!\r\n" +
+				"Content-Length: 0\r\n" +
+				"\r\n"),
+			want: Headers{
+				"Subject":        {"I know you're there, pick up the phone and talk to me!"},
+				"Content-Length": {"0"},
+			},
+		},
+		{
+			input: []byte(wsinv),
+			want: Headers{
+				"To":           {"sip:vivekg@chair-dnrc.example.com ;   tag    = 1918181833n"},
+				"From":         {"\"J Rosenberg \\\\\\\"\"       <sip:jdrosen@example.com> ; tag = 98asjd8"},
+				"Max-Forwards": {"0068"},
+				"Call-ID":      {"wsinv.ndaksdj@192.0.2.1"},
+				"CSeq":         {"0009 INVITE"},
+				"Via": {
+					"SIP  /   2.0 /UDP 192.0.2.2;branch=390skdjuw",
+					"SIP  / 2.0  / TCP     spindle.example.com   ; branch  =   z9hG4bK9ikj8",
+					"SIP  /    2.0   / UDP  192.168.255.111   ; branch= z9hG4bK30239",
+				},
+				"Subject":                       {""},
+				"NewFangledHeader":              {"newfangled value continued newfangled value"},
+				"UnknownHeaderWithUnusualValue": {";;,,;;,;"},
+				"Content-Type":                  {"application/sdp"},
+				"Content-Length":                {"150"},
+				"Route":                         {"<sip:services.example.com;lr;unknownwith=value;unknown-no-value>"},
+				"Contact":                       {"\"Quoted string \\\"\\\"\" <sip:jdrosen@example.com> ; newparam = newvalue ; secondparam ; q = 0.33"},
+			},
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			got := new(Message)
+			err := Unmarshal(test.input, got)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, got.Headers, test.want)
+		})
+	}
+}
+
+func TestUnmarshalFoldedHeadersHaveTypedValues(t *testing.T) {
+	got := new(Message)
+
+	assert.Nil(t, Unmarshal([]byte(wsinv), got))
+
+	cseq, err := got.Headers.CSeq()
+
+	assert.Nil(t, err)
+	assert.Equal(t, &CSeq{Sequence: 9, Method: MethodInvite}, cseq)
+
+	hops, err := got.Headers.MaxForwards()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 68, hops)
+
+	vias, err := got.Headers.Via()
+
+	assert.Nil(t, err)
+	assert.Len(t, vias, 3)
+	assert.Equal(t, "390skdjuw", vias[0].Branch)
+	assert.Equal(t, "spindle.example.com", vias[1].Host)
+	assert.Equal(t, "TCP", vias[1].Transport)
+	assert.Equal(t, "z9hG4bK30239", vias[2].Branch)
+
+	from, err := got.Headers.From()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "98asjd8", from.Tag())
+	assert.Equal(t, "jdrosen", from.User)
+}
@@ -29,15 +29,13 @@ func ValidateMetadata(metadata Metadata, fields []string) error {
 }
 
 // ValidateHeaders ensures that all fields present in fields are in headers.
+//
+// Header names are compared in their canonical format, so compact forms and any casing are accepted.
 func ValidateHeaders(headers Headers, fields []string) error {
-	hashMap := make(map[string]interface{})
-
-	for k, v := range headers {
-		hashMap[k] = v
-	}
-
-	if err := validateRequiredKeysOnMap(hashMap, fields); err != nil {
-		return ErrMissingRequiredHeader
+	for _, field := range fields {
+		if !headers.Has(field) {
+			return ErrMissingRequiredHeader
+		}
 	}
 
 	return nil
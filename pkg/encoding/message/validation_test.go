@@ -100,4 +100,19 @@ func TestValidateHedars(t *testing.T) {
 
 		assert.ErrorIs(t, err, ErrMissingRequiredHeader)
 	})
+
+	t.Run("Returns nill if keys are present in another case or form", func(t *testing.T) {
+		fields := []string{"Call-ID", "Content-Length", "Via", "CSeq"}
+
+		headers := Headers{
+			"i":    {"a84b4c76e66710"},
+			"l":    {"142"},
+			"VIA":  {"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds"},
+			"cseq": {"314159 INVITE"},
+		}
+
+		err := ValidateHeaders(headers, fields)
+
+		assert.Nil(t, err)
+	})
 }
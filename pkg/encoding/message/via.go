@@ -0,0 +1,81 @@
+package message
+
+import (
+	"fmt"
+
+	"github.com/otoru/party/pkg/encoding/header"
+)
+
+// Via returns the parsed values of the Via header, from the topmost to the bottommost.
+func (headers Headers) Via() ([]*header.Via, error) {
+	values := headers.Values("Via")
+	result := make([]*header.Via, 0, len(values))
+
+	for _, value := range values {
+		via := new(header.Via)
+
+		if err := header.UnmarshalVia(value, via); err != nil {
+			return nil, fmt.Errorf("%w: Via: %s", ErrInvalidSipMessage, err)
+		}
+
+		result = append(result, via)
+	}
+
+	return result, nil
+}
+
+// TopVia returns the parsed value of the topmost Via header.
+func (headers Headers) TopVia() (*header.Via, error) {
+	value := headers.Get("Via")
+
+	if value == "" {
+		return nil, ErrMissingRequiredHeader
+	}
+
+	via := new(header.Via)
+
+	if err := header.UnmarshalVia(value, via); err != nil {
+		return nil, fmt.Errorf("%w: Via: %s", ErrInvalidSipMessage, err)
+	}
+
+	return via, nil
+}
+
+// PushVia inserts via as the topmost Via header, as a proxy does before forwarding a request. Marshal with
+// WithPreservedOrder emits it above the Via headers of Message.Order.
+func (headers Headers) PushVia(via *header.Via) error {
+	value, err := header.MarshalVia(via)
+
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrOnGenerateSIPMessage, err)
+	}
+
+	key, ok := headers.key("Via")
+
+	if !ok {
+		key = "Via"
+	}
+
+	headers[key] = append([]string{value}, headers[key]...)
+
+	return nil
+}
+
+// PopVia removes the topmost Via header and returns its parsed value, as a proxy does before forwarding a response.
+func (headers Headers) PopVia() (*header.Via, error) {
+	via, err := headers.TopVia()
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, _ := headers.key("Via")
+
+	if values := headers[key][1:]; len(values) > 0 {
+		headers[key] = values
+	} else {
+		delete(headers, key)
+	}
+
+	return via, nil
+}
@@ -0,0 +1,135 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/otoru/party/pkg/encoding/header"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadersVia(t *testing.T) {
+	t.Run("Returns every Via value from top to bottom", func(t *testing.T) {
+		headers := Headers{
+			"Via": {
+				"SIP/2.0/UDP server10.biloxi.com;branch=z9hG4bKnashds8",
+				"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds;received=192.0.2.1",
+			},
+		}
+
+		vias, err := headers.Via()
+
+		assert.Nil(t, err)
+		assert.Len(t, vias, 2)
+		assert.Equal(t, "server10.biloxi.com", vias[0].Host)
+		assert.Equal(t, "192.0.2.1", vias[1].Received)
+	})
+
+	t.Run("Returns an error if a value is invalid", func(t *testing.T) {
+		headers := Headers{
+			"v": {"SIP/2.0 server10.biloxi.com"},
+		}
+
+		vias, err := headers.Via()
+
+		assert.Nil(t, vias)
+		assert.ErrorIs(t, err, ErrInvalidSipMessage)
+	})
+}
+
+func TestHeadersPushAndPopVia(t *testing.T) {
+	t.Run("Pushes and pops the topmost Via", func(t *testing.T) {
+		headers := Headers{
+			"Via": {"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds"},
+		}
+
+		err := headers.PushVia(&header.Via{
+			Protocol:  "SIP",
+			Version:   "2.0",
+			Transport: "UDP",
+			Host:      "server10.biloxi.com",
+			Branch:    "z9hG4bKnashds8",
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"SIP/2.0/UDP server10.biloxi.com;branch=z9hG4bKnashds8",
+			"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds",
+		}, headers["Via"])
+
+		via, err := headers.PopVia()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "z9hG4bKnashds8", via.Branch)
+		assert.Equal(t, []string{"SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds"}, headers["Via"])
+
+		via, err = headers.PopVia()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "pc33.atlanta.com", via.Host)
+		assert.False(t, headers.Has("Via"))
+	})
+
+	t.Run("Keeps the pushed Via on top with the preserved order", func(t *testing.T) {
+		message := new(Message)
+
+		err := Unmarshal([]byte("INVITE sip:bob@biloxi.com SIP/2.0\r\n"+
+			"Call-ID: a84b4c76e66710\r\n"+
+			"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n"+
+			"CSeq: 314159 INVITE\r\n"+
+			"Content-Length: 0\r\n"+
+			"\r\n"), message)
+
+		assert.Nil(t, err)
+
+		err = message.Headers.PushVia(&header.Via{
+			Protocol:  "SIP",
+			Version:   "2.0",
+			Transport: "UDP",
+			Host:      "server10.biloxi.com",
+			Branch:    "z9hG4bKnashds8",
+		})
+
+		assert.Nil(t, err)
+
+		result, err := Marshal(message, WithPreservedOrder())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "INVITE sip:bob@biloxi.com SIP/2.0\r\n"+
+			"Call-ID: a84b4c76e66710\r\n"+
+			"Via: SIP/2.0/UDP server10.biloxi.com;branch=z9hG4bKnashds8\r\n"+
+			"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n"+
+			"CSeq: 314159 INVITE\r\n"+
+			"Content-Length: 0\r\n"+
+			"\r\n", string(result))
+
+		_, err = message.Headers.PopVia()
+
+		assert.Nil(t, err)
+
+		result, err = Marshal(message, WithPreservedOrder())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "INVITE sip:bob@biloxi.com SIP/2.0\r\n"+
+			"Call-ID: a84b4c76e66710\r\n"+
+			"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n"+
+			"CSeq: 314159 INVITE\r\n"+
+			"Content-Length: 0\r\n"+
+			"\r\n", string(result))
+	})
+
+	t.Run("Returns an error when there is no Via to pop", func(t *testing.T) {
+		via, err := Headers{}.PopVia()
+
+		assert.Nil(t, via)
+		assert.ErrorIs(t, err, ErrMissingRequiredHeader)
+	})
+
+	t.Run("Returns an error when pushing an invalid Via", func(t *testing.T) {
+		headers := Headers{}
+
+		err := headers.PushVia(&header.Via{Protocol: "SIP"})
+
+		assert.ErrorIs(t, err, ErrOnGenerateSIPMessage)
+		assert.Empty(t, headers)
+	})
+}
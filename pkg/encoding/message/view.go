@@ -0,0 +1,493 @@
+package message
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/header"
+)
+
+// span is the position of a slice of the payload of a View.
+type span struct {
+	start int
+	end   int
+}
+
+// field is a header found in the payload of a View, where value includes its continuation lines, if any.
+type field struct {
+	name  span
+	value span
+}
+
+// compactTable maps each lower case letter to the long form of the header name it abbreviates, if any.
+var compactTable = func() (table [26]string) {
+	for compact, long := range compactForms {
+		table[compact[0]-'a'] = long
+	}
+
+	return table
+}()
+
+// View is a read-only view of a SIP message that indexes the raw payload instead of copying it.
+//
+// Building a View only locates the start line, the header lines and the body. Header values are returned as slices
+// of the payload and are parsed only when a typed accessor, such as TopVia or From, is called, so code that reads
+// a handful of headers pays nothing for the others. A View can be reused through UnmarshalView, which then does not
+// allocate once its index has grown to the number of headers of the messages it sees.
+//
+// The slices returned by a View share memory with the payload, which must not be modified while the View is used.
+type View struct {
+	payload []byte
+	kind    string
+	start   [3]span
+	fields  []field
+	body    span
+}
+
+// UnmarshalView indexes the SIP-encoded data and stores the result in the View pointed to by view.
+//
+// Only the framing of the message is checked: the shape of the start line, the colon of each header line, the
+// blank line and the Content-Length header, which truncates the body as in Unmarshal. Use Message, or Unmarshal,
+// for the full checks.
+//
+// The limits given with WithLimits, or DefaultLimits, are enforced with the same errors as Unmarshal, except for
+// ValuesPerHeader since a View does not split header values. Other options are ignored.
+func UnmarshalView(payload []byte, view *View, options ...UnmarshalOption) error {
+	view.payload = payload
+	view.fields = view.fields[:0]
+
+	limits := DefaultLimits
+
+	if len(options) > 0 {
+		limits = newUnmarshalOptions(options).limits
+	}
+
+	if exceeds(len(payload), limits.MessageSize) {
+		return overLimit(ErrMessageTooLarge, "message size", len(payload), limits.MessageSize)
+	}
+
+	end := bytes.Index(payload, CRLF)
+
+	if end < 0 {
+		return &ParseError{Line: 1, Reason: "missing blank line after headers", Err: ErrInvalidSipMessage}
+	}
+
+	if exceeds(end, limits.HeaderLineLength) {
+		return locate(overLimit(ErrHeaderLineTooLong, "line length", end, limits.HeaderLineLength), 1, 0, "")
+	}
+
+	if err := view.indexStartLine(end); err != nil {
+		return err
+	}
+
+	number := 1
+	offset := end + len(CRLF)
+
+	for {
+		number++
+
+		end := bytes.Index(payload[offset:], CRLF)
+
+		if end < 0 {
+			return &ParseError{Line: number, Offset: offset, Reason: "missing blank line after headers", Err: ErrInvalidSipMessage}
+		}
+
+		end += offset
+
+		if exceeds(end-offset, limits.HeaderLineLength) {
+			return locate(overLimit(ErrHeaderLineTooLong, "line length", end-offset, limits.HeaderLineLength), number, offset, "")
+		}
+
+		switch {
+		case end == offset:
+			// We found the blank line
+			return view.indexBody(end+len(CRLF), number, limits.BodySize)
+
+		case payload[offset] == ' ' || payload[offset] == '\t':
+			// We found a continuation of the previous header
+			if len(view.fields) == 0 {
+				return &ParseError{Line: number, Offset: offset, Reason: "continuation line without header", Err: ErrInvalidSipMessage}
+			}
+
+			last := &view.fields[len(view.fields)-1]
+			continuation := trim(payload, span{offset, end})
+
+			if continuation.start == continuation.end {
+				break
+			}
+
+			if last.value.start == last.value.end {
+				last.value.start = continuation.start
+			}
+
+			last.value.end = continuation.end
+
+		default:
+			colon := bytes.IndexByte(payload[offset:end], ':')
+
+			if colon < 0 {
+				return &ParseError{Line: number, Offset: offset, Reason: "missing colon after header name", Err: ErrInvalidSipMessage}
+			}
+
+			if exceeds(len(view.fields)+1, limits.Headers) {
+				return locate(overLimit(ErrTooManyHeaders, "header count", len(view.fields)+1, limits.Headers), number, offset, "")
+			}
+
+			name := trim(payload, span{offset, offset + colon})
+			value := trim(payload, span{offset + colon + 1, end})
+
+			view.fields = append(view.fields, field{name: name, value: value})
+		}
+
+		offset = end + len(CRLF)
+	}
+}
+
+// indexStartLine locates the elements of the start line, which ends at end.
+func (view *View) indexStartLine(end int) error {
+	line := view.payload[:end]
+
+	view.kind = Request
+	reason := "malformed request line"
+
+	if bytes.HasPrefix(line, []byte("SIP/")) {
+		view.kind = Response
+		reason = "malformed status line"
+	}
+
+	first := bytes.IndexByte(line, ' ')
+	second := -1
+
+	if first > 0 {
+		second = bytes.IndexByte(line[first+1:], ' ')
+	}
+
+	if second < 0 {
+		return &ParseError{Line: 1, Reason: reason, Err: ErrInvalidSipMessage}
+	}
+
+	second += first + 1
+
+	view.start = [3]span{{0, first}, {first + 1, second}, {second + 1, end}}
+
+	// The reason phrase of a status line may be empty and contain spaces, but no element of a request line may
+	if view.kind == Request && (second == first+1 || second+1 == end || bytes.IndexByte(line[second+1:], ' ') >= 0) {
+		return &ParseError{Line: 1, Reason: reason, Err: ErrInvalidSipMessage}
+	}
+
+	return nil
+}
+
+// indexBody locates the body, which starts at start, truncating it to the Content-Length header if there is one, and
+// checks that it is not larger than limit.
+func (view *View) indexBody(start int, line int, limit int) error {
+	view.body = span{start, len(view.payload)}
+
+	value := view.Get("Content-Length")
+
+	if value == nil {
+		if exceeds(view.body.end-start, limit) {
+			return locate(overLimit(ErrBodyTooLarge, "body size", view.body.end-start, limit), line+1, start, "Content-Length")
+		}
+
+		return nil
+	}
+
+	length := 0
+
+	for _, char := range value {
+		if char < '0' || char > '9' || length > maxSequence/10 {
+			length = -1
+
+			break
+		}
+
+		length = length*10 + int(char-'0')
+	}
+
+	if len(value) == 0 || length < 0 {
+		return &ParseError{Line: line + 1, Offset: start, Header: "Content-Length", Reason: "invalid value", Err: ErrInvalidSipMessage}
+	}
+
+	if exceeds(length, limit) {
+		return locate(overLimit(ErrBodyTooLarge, "body size", length, limit), line+1, start, "Content-Length")
+	}
+
+	if length > view.body.end-start {
+		return &ParseError{
+			Line:   line + 1,
+			Offset: start,
+			Header: "Content-Length",
+			Reason: fmt.Sprintf("body has %d octets but %d were declared", view.body.end-start, length),
+			Err:    ErrInvalidBodyOnSIPMessage,
+		}
+	}
+
+	view.body.end = start + length
+
+	return nil
+}
+
+// Kind returns Request or Response.
+func (view *View) Kind() string {
+	return view.kind
+}
+
+// Method returns the method of a request, or nil if the view is not a request.
+func (view *View) Method() []byte {
+	if view.kind != Request {
+		return nil
+	}
+
+	return view.slice(view.start[0])
+}
+
+// RequestURI returns the Request-URI of a request, or nil if the view is not a request.
+func (view *View) RequestURI() []byte {
+	if view.kind != Request {
+		return nil
+	}
+
+	return view.slice(view.start[1])
+}
+
+// StatusCode returns the status code of a response, or 0 if the view is not a response or its code is invalid.
+func (view *View) StatusCode() StatusCode {
+	if view.kind != Response {
+		return 0
+	}
+
+	value := view.slice(view.start[1])
+
+	if len(value) != 3 {
+		return 0
+	}
+
+	code := 0
+
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return 0
+		}
+
+		code = code*10 + int(char-'0')
+	}
+
+	if code < 100 || code > 699 {
+		return 0
+	}
+
+	return StatusCode(code)
+}
+
+// Reason returns the reason phrase of a response, or nil if the view is not a response.
+func (view *View) Reason() []byte {
+	if view.kind != Response {
+		return nil
+	}
+
+	return view.slice(view.start[2])
+}
+
+// Body returns the body, truncated to the Content-Length header if there is one, or nil if it is empty.
+func (view *View) Body() []byte {
+	if view.body.start == view.body.end {
+		return nil
+	}
+
+	return view.slice(view.body)
+}
+
+// Get returns the first value of the header name, matched regardless of case or compact form, or nil if there is
+// none. The value is returned as found in the payload, so it keeps any continuation line and, for comma-separated
+// lists, every element of the first header line.
+func (view *View) Get(name string) []byte {
+	for _, field := range view.fields {
+		if view.matches(field.name, name) {
+			return view.slice(field.value)
+		}
+	}
+
+	return nil
+}
+
+// Has reports whether the header name is present.
+func (view *View) Has(name string) bool {
+	return view.Get(name) != nil
+}
+
+// Values returns the values of the header name, with continuation lines folded and comma-separated lists split,
+// as stored by Unmarshal.
+func (view *View) Values(name string) ([]string, error) {
+	var values []string
+
+	for _, field := range view.fields {
+		if !view.matches(field.name, name) {
+			continue
+		}
+
+		elements, err := splitHeaderValue(name, fold(view.slice(field.value)))
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, elements...)
+	}
+
+	return values, nil
+}
+
+// TopVia returns the parsed topmost value of the Via header.
+func (view *View) TopVia() (*header.Via, error) {
+	return view.headers("Via").TopVia()
+}
+
+// Route returns the parsed values of the Route header, in order.
+func (view *View) Route() ([]*header.NameAddr, error) {
+	return view.headers("Route").NameAddrs("Route")
+}
+
+// From returns the parsed value of the From header.
+func (view *View) From() (*header.NameAddr, error) {
+	return view.headers("From").From()
+}
+
+// To returns the parsed value of the To header.
+func (view *View) To() (*header.NameAddr, error) {
+	return view.headers("To").To()
+}
+
+// CallID returns the value of the Call-ID header, or nil if there is none.
+func (view *View) CallID() []byte {
+	return view.Get("Call-ID")
+}
+
+// Message returns a Message with the full content of the view, as parsed and checked by Unmarshal.
+func (view *View) Message() (*Message, error) {
+	message := new(Message)
+
+	if err := Unmarshal(view.payload, message); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// headers returns Headers holding only the values of the header name, for use by the typed accessors.
+func (view *View) headers(name string) Headers {
+	headers := make(Headers, 1)
+
+	values, err := view.Values(name)
+
+	if err != nil || len(values) == 0 {
+		return headers
+	}
+
+	headers[CanonicalHeaderKey(name)] = values
+
+	return headers
+}
+
+// matches reports whether found, a header name of the payload, is name in any case or form, without allocating.
+func (view *View) matches(found span, name string) bool {
+	value := view.slice(found)
+
+	if equalFold(value, name) {
+		return true
+	}
+
+	if len(value) == 1 {
+		if long := compactLong(value[0]); long != "" {
+			return strings.EqualFold(long, name)
+		}
+	}
+
+	if len(name) == 1 {
+		if long := compactLong(name[0]); long != "" {
+			return equalFold(value, long)
+		}
+	}
+
+	return false
+}
+
+// slice returns the part of the payload at position.
+func (view *View) slice(position span) []byte {
+	return view.payload[position.start:position.end:position.end]
+}
+
+// compactLong returns the long form of the compact header name char, or an empty string if there is none.
+func compactLong(char byte) string {
+	char |= 0x20
+
+	if char < 'a' || char > 'z' {
+		return ""
+	}
+
+	return compactTable[char-'a']
+}
+
+// equalFold reports whether value and name are equal under ASCII case folding.
+func equalFold(value []byte, name string) bool {
+	if len(value) != len(name) {
+		return false
+	}
+
+	for index := 0; index < len(value); index++ {
+		a, b := value[index], name[index]
+
+		if a >= 'A' && a <= 'Z' {
+			a += 'a' - 'A'
+		}
+
+		if b >= 'A' && b <= 'Z' {
+			b += 'a' - 'A'
+		}
+
+		if a != b {
+			return false
+		}
+	}
+
+	return true
+}
+
+// trim returns position without the leading and trailing spaces and tabs of payload.
+func trim(payload []byte, position span) span {
+	for position.start < position.end && (payload[position.start] == ' ' || payload[position.start] == '\t') {
+		position.start++
+	}
+
+	for position.end > position.start && (payload[position.end-1] == ' ' || payload[position.end-1] == '\t') {
+		position.end--
+	}
+
+	return position
+}
+
+// fold returns value with each line folding replaced by a single space, as defined in RFC 3261 section 7.3.1.
+func fold(value []byte) string {
+	if bytes.IndexByte(value, '\n') < 0 {
+		return string(value)
+	}
+
+	var builder strings.Builder
+
+	for _, line := range bytes.Split(value, CRLF) {
+		line = bytes.TrimSpace(line)
+
+		if len(line) == 0 {
+			continue
+		}
+
+		if builder.Len() > 0 {
+			builder.WriteByte(' ')
+		}
+
+		builder.Write(line)
+	}
+
+	return builder.String()
+}
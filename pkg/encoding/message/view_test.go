@@ -0,0 +1,281 @@
+package message
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewMatchesUnmarshal(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*", "valid", "*.dat"))
+
+	assert.Nil(t, err)
+
+	inputs := map[string][]byte{
+		"decoderInvite":   []byte(decoderInvite),
+		"decoderResponse": []byte(decoderResponse),
+		"wsinv":           []byte(wsinv),
+	}
+
+	for _, path := range paths {
+		payload, err := os.ReadFile(path)
+
+		assert.Nil(t, err)
+
+		inputs[path] = payload
+	}
+
+	for name, payload := range inputs {
+		t.Run(name, func(t *testing.T) {
+			want := new(Message)
+
+			assert.Nil(t, Unmarshal(payload, want))
+
+			view := new(View)
+
+			assert.Nil(t, UnmarshalView(payload, view))
+
+			assert.Equal(t, want.Kind, view.Kind())
+
+			if want.Kind == Request {
+				assert.Equal(t, want.Metadata["method"], string(view.Method()))
+				assert.Equal(t, want.Metadata["uri"], string(view.RequestURI()))
+			} else {
+				assert.Equal(t, want.StatusCode(), view.StatusCode())
+				assert.Equal(t, want.Metadata["reason"], string(view.Reason()))
+			}
+
+			for _, key := range want.Order {
+				values, err := view.Values(key)
+
+				assert.Nil(t, err)
+
+				assert.Equal(t, want.Headers.Values(key), values, key)
+			}
+
+			assert.Equal(t, want.Body, view.Body())
+
+			got, err := view.Message()
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestViewHeaders(t *testing.T) {
+	view := new(View)
+
+	assert.Nil(t, UnmarshalView([]byte(decoderResponse), view))
+
+	t.Run("matches names regardless of case and form", func(t *testing.T) {
+		for _, name := range []string{"Content-Length", "content-length", "CONTENT-LENGTH", "l", "L"} {
+			assert.Equal(t, []byte("0"), view.Get(name), name)
+		}
+
+		assert.Equal(t, []byte("a84b4c76e66710"), view.Get("i"))
+	})
+
+	t.Run("returns nil for missing headers", func(t *testing.T) {
+		assert.Nil(t, view.Get("Route"))
+
+		assert.False(t, view.Has("Route"))
+
+		routes, err := view.Route()
+
+		assert.Nil(t, err)
+
+		assert.Empty(t, routes)
+	})
+
+	t.Run("parses the routing headers", func(t *testing.T) {
+		via, err := view.TopVia()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "z9hG4bK776asdhds", via.Branch)
+
+		from, err := view.From()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "1928301774", from.Tag())
+
+		to, err := view.To()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "a6c85cf", to.Tag())
+
+		assert.Equal(t, []byte("a84b4c76e66710"), view.CallID())
+	})
+
+	t.Run("keeps folded values as found", func(t *testing.T) {
+		assert.Nil(t, UnmarshalView([]byte(wsinv), view))
+
+		assert.Equal(t, []byte("newfangled value\r\n continued newfangled value"), view.Get("NewFangledHeader"))
+
+		routes, err := view.Route()
+
+		assert.Nil(t, err)
+		assert.Len(t, routes, 1)
+		assert.Equal(t, "services.example.com", routes[0].Host)
+	})
+}
+
+func TestUnmarshalViewWithInvalidMessages(t *testing.T) {
+	table := []struct {
+		input []byte
+		want  *ParseError
+	}{
+		{
+			input: []byte("INVITE sip:bob@biloxi.com\r\n\r\n"),
+			want:  &ParseError{Line: 1, Reason: "malformed request line", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("SIP/2.0 200\r\n\r\n"),
+			want:  &ParseError{Line: 1, Reason: "malformed status line", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Call-ID: a84b4c76e66710\r\n" +
+				"Watson come here\r\n" +
+				"\r\n"),
+			want: &ParseError{Line: 3, Offset: 61, Reason: "missing colon after header name", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				" SIP/2.0/UDP 192.168.0.3:5060;branch=z9hG4bK3\r\n" +
+				"\r\n"),
+			want: &ParseError{Line: 2, Offset: 36, Reason: "continuation line without header", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"Call-ID: a84b4c76e66710\r\n"),
+			want: &ParseError{Line: 3, Offset: 61, Reason: "missing blank line after headers", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"l: ten\r\n" +
+				"\r\n"),
+			want: &ParseError{Line: 4, Offset: 46, Header: "Content-Length", Reason: "invalid value", Err: ErrInvalidSipMessage},
+		},
+		{
+			input: []byte("OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+				"l: 10\r\n" +
+				"\r\n" +
+				"Watson"),
+			want: &ParseError{
+				Line:   4,
+				Offset: 45,
+				Header: "Content-Length",
+				Reason: "body has 6 octets but 10 were declared",
+				Err:    ErrInvalidBodyOnSIPMessage,
+			},
+		},
+	}
+
+	for index, test := range table {
+		t.Run(fmt.Sprintf("Test N° %d", index), func(t *testing.T) {
+			var got *ParseError
+
+			err := UnmarshalView(test.input, new(View))
+
+			assert.ErrorIs(t, err, test.want.Err)
+
+			assert.True(t, errors.As(err, &got))
+
+			assert.Equal(t, got, test.want)
+		})
+	}
+}
+
+func TestUnmarshalViewDoesNotAllocate(t *testing.T) {
+	payload := []byte(decoderInvite)
+	view := new(View)
+
+	allocations := testing.AllocsPerRun(100, func() {
+		if err := UnmarshalView(payload, view); err != nil {
+			t.Fatal(err)
+		}
+
+		view.Method()
+		view.RequestURI()
+		view.Get("Via")
+		view.Get("Route")
+		view.Get("To")
+		view.Get("From")
+		view.CallID()
+		view.Body()
+	})
+
+	assert.Equal(t, float64(0), allocations)
+}
+
+// The benchmarks below compare the cost of reading the headers a proxy needs to route a request.
+
+func BenchmarkUnmarshal(b *testing.B) {
+	payload := []byte(wsinv)
+
+	b.ReportAllocs()
+
+	for index := 0; index < b.N; index++ {
+		message := new(Message)
+
+		if err := Unmarshal(payload, message); err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := message.Headers.TopVia(); err != nil {
+			b.Fatal(err)
+		}
+
+		message.Headers.Get("Route")
+		message.Headers.Get("To")
+		message.Headers.Get("From")
+		message.Headers.Get("Call-ID")
+	}
+}
+
+func BenchmarkUnmarshalView(b *testing.B) {
+	payload := []byte(wsinv)
+	view := new(View)
+
+	b.ReportAllocs()
+
+	for index := 0; index < b.N; index++ {
+		if err := UnmarshalView(payload, view); err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := view.TopVia(); err != nil {
+			b.Fatal(err)
+		}
+
+		view.Get("Route")
+		view.Get("To")
+		view.Get("From")
+		view.Get("Call-ID")
+	}
+}
+
+func BenchmarkUnmarshalViewRawHeaders(b *testing.B) {
+	payload := []byte(wsinv)
+	view := new(View)
+
+	b.ReportAllocs()
+
+	for index := 0; index < b.N; index++ {
+		if err := UnmarshalView(payload, view); err != nil {
+			b.Fatal(err)
+		}
+
+		view.Get("Via")
+		view.Get("Route")
+		view.Get("To")
+		view.Get("From")
+		view.Get("Call-ID")
+	}
+}
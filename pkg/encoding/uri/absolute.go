@@ -0,0 +1,94 @@
+package uri
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// AbsoluteURI is a URI of any scheme that may appear in a SIP message, such as in the Request-URI or in the
+// Contact, Call-Info, Alert-Info and Error-Info headers. It is implemented by *URI for SIP and SIPS URIs, *TelURI
+// for tel URIs, *URN for URNs and *GenericURI for any other scheme.
+type AbsoluteURI interface {
+	// SchemeName returns the scheme of the URI in lower case, such as "sip", "tel" or "mailto".
+	SchemeName() string
+
+	// String returns the string representation of the URI.
+	String() string
+}
+
+// GenericURI is a struct that represents a URI of a scheme without a dedicated type, such as mailto, http, im or
+// pres, as parsed by the net/url package.
+type GenericURI struct {
+	url.URL
+}
+
+// SchemeName returns the scheme of generic in lower case.
+func (generic *GenericURI) SchemeName() string {
+	return strings.ToLower(generic.Scheme)
+}
+
+// Parse parses a URI of any scheme, returning a *URI for SIP and SIPS URIs, a *TelURI for tel URIs, a *URN for
+// URNs and a *GenericURI for any other scheme. Callers can switch on the type of the result, or only use the
+// methods of AbsoluteURI.
+//
+// It returns a *URIError if the URI is invalid, wrapping the error of the parser of its scheme, or ErrInvalidURI
+// for a URI without a valid scheme.
+func Parse(payload string) (AbsoluteURI, error) {
+	scheme, _, found := strings.Cut(payload, ":")
+
+	if !found {
+		return nil, &URIError{URI: payload, Reason: "missing scheme", Err: ErrInvalidURI}
+	}
+
+	if !isScheme(scheme) {
+		return nil, &URIError{URI: payload, Reason: "invalid scheme", Err: ErrInvalidURI}
+	}
+
+	switch strings.ToLower(scheme) {
+	case "sip", "sips":
+		value := new(URI)
+
+		if err := Unmarshal(payload, value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	case "tel":
+		value := new(TelURI)
+
+		if err := UnmarshalTel(payload, value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	case "urn":
+		value := new(URN)
+
+		if err := UnmarshalURN(payload, value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	}
+
+	if strings.ContainsAny(payload, " \t\r\n") {
+		return nil, &URIError{URI: payload, Reason: "whitespace is not allowed", Err: ErrInvalidURI}
+	}
+
+	parsed, err := url.Parse(payload)
+
+	if err != nil {
+		var urlError *url.Error
+
+		reason := err.Error()
+
+		if errors.As(err, &urlError) {
+			reason = urlError.Err.Error()
+		}
+
+		return nil, &URIError{URI: payload, Reason: reason, Err: ErrInvalidURI}
+	}
+
+	return &GenericURI{URL: *parsed}, nil
+}
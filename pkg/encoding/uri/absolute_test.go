@@ -0,0 +1,114 @@
+package uri
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWithValidCases(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		scheme  string
+		want    AbsoluteURI
+	}{
+		{
+			name:    "Test SIP URI",
+			payload: "sip:alice@atlanta.com;transport=tcp",
+			scheme:  "sip",
+			want:    &URI{Scheme: "sip", User: "alice", Host: "atlanta.com", Parameters: map[string]string{"transport": "tcp"}, Headers: map[string]string{}},
+		},
+		{
+			name:    "Test SIPS URI in upper case",
+			payload: "SIPS:bob@biloxi.com",
+			scheme:  "sips",
+			want:    &URI{Scheme: "SIPS", User: "bob", Host: "biloxi.com", Parameters: map[string]string{}, Headers: map[string]string{}},
+		},
+		{
+			name:    "Test tel URI",
+			payload: "tel:+15551234567;phone-context=example.com",
+			scheme:  "tel",
+			want:    &TelURI{Number: "+15551234567", PhoneContext: "example.com", Parameters: map[string]string{}},
+		},
+		{
+			name:    "Test service URN",
+			payload: "urn:service:sos",
+			scheme:  "urn",
+			want:    &URN{NID: "service", NSS: "sos"},
+		},
+		{
+			name:    "Test mailto URI",
+			payload: "mailto:alice@example.com",
+			scheme:  "mailto",
+			want:    &GenericURI{URL: url.URL{Scheme: "mailto", Opaque: "alice@example.com"}},
+		},
+		{
+			name:    "Test http URI",
+			payload: "http://www.example.com/alice/photo.jpg",
+			scheme:  "http",
+			want:    &GenericURI{URL: url.URL{Scheme: "http", Host: "www.example.com", Path: "/alice/photo.jpg"}},
+		},
+		{
+			name:    "Test instant messaging URI",
+			payload: "im:alice@example.com",
+			scheme:  "im",
+			want:    &GenericURI{URL: url.URL{Scheme: "im", Opaque: "alice@example.com"}},
+		},
+		{
+			name:    "Test presence URI",
+			payload: "pres:alice@example.com",
+			scheme:  "pres",
+			want:    &GenericURI{URL: url.URL{Scheme: "pres", Opaque: "alice@example.com"}},
+		},
+		{
+			name:    "Test unknown scheme of RFC 4475",
+			payload: "nobarr.url.sip:user@example.com",
+			scheme:  "nobarr.url.sip",
+			want:    &GenericURI{URL: url.URL{Scheme: "nobarr.url.sip", Opaque: "user@example.com"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.payload)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.scheme, got.SchemeName())
+			assert.Equal(t, tc.payload, got.String())
+		})
+	}
+}
+
+func TestParseWithInvalidCases(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     string
+		expectedErr error
+	}{
+		{name: "Test missing scheme", payload: "bellcore-dr2", expectedErr: ErrInvalidURI},
+		{name: "Test invalid scheme", payload: "<sip:alice@atlanta.com>", expectedErr: ErrInvalidURI},
+		{name: "Test invalid SIP URI", payload: "sip:user@2001:db8::10", expectedErr: ErrInvalidSipURI},
+		{name: "Test invalid tel URI", payload: "tel:7042", expectedErr: ErrInvalidTelURI},
+		{name: "Test invalid URN", payload: "urn:service", expectedErr: ErrInvalidURN},
+		{name: "Test whitespace inside URI", payload: "mailto:alice @example.com", expectedErr: ErrInvalidURI},
+		{name: "Test invalid escape", payload: "http://www.example.com/%zz", expectedErr: ErrInvalidURI},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.payload)
+
+			assert.Nil(t, got)
+			assert.ErrorIs(t, err, tc.expectedErr)
+
+			var uriError *URIError
+
+			assert.True(t, errors.As(err, &uriError))
+			assert.Equal(t, tc.payload, uriError.URI)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package uri
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// significantParameters holds the parameters that make two URIs different even when only one of them has it, as
+// defined in RFC 3261 section 19.1.4.
+var significantParameters = []string{"maddr", "method", "transport", "ttl", "user"}
+
+// Equal reports whether a and b are equivalent, following the rules of RFC 3261 section 19.1.4:
+//
+//   - SIP and SIPS URIs are never equivalent, and the scheme is compared without regard to case.
+//   - The user and password are compared with regard to case, and the host and parameters without it.
+//   - Escaped and unescaped forms are equivalent, as Unmarshal replaces the escapes.
+//   - A port, or a maddr, method, transport, ttl or user parameter, present in only one URI makes them differ. Any
+//     other parameter present in only one URI is ignored.
+//   - Headers must be present in both URIs, with the same values.
+func Equal(a *URI, b *URI) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if !strings.EqualFold(a.Scheme, b.Scheme) || a.User != b.User || a.Password != b.Password {
+		return false
+	}
+
+	if canonicalHost(a.Host) != canonicalHost(b.Host) || a.Port != b.Port {
+		return false
+	}
+
+	parametersOfA, parametersOfB := canonicalParameters(a.Parameters), canonicalParameters(b.Parameters)
+
+	for name, value := range parametersOfA {
+		if other, ok := parametersOfB[name]; ok && !strings.EqualFold(value, other) {
+			return false
+		}
+	}
+
+	for _, name := range significantParameters {
+		_, inA := parametersOfA[name]
+		_, inB := parametersOfB[name]
+
+		if inA != inB {
+			return false
+		}
+	}
+
+	if len(a.Headers) != len(b.Headers) {
+		return false
+	}
+
+	for name, value := range a.Headers {
+		if other, ok := b.Headers[name]; !ok || value != other {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Key returns a canonical form of uri, suited for use as a map key, such as for a table of registered bindings.
+//
+// Equivalent URIs have the same key. The key only holds the parameters listed by Equal as significant when present
+// in a single URI, so URIs that differ in other parameters present in both also share a key.
+func Key(uri *URI) string {
+	if uri == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(strings.ToLower(uri.Scheme))
+	builder.WriteString(":")
+
+	if uri.User != "" || uri.Password != "" {
+		builder.WriteString(escape(uri.User, userUnreserved))
+		builder.WriteString(":")
+		builder.WriteString(escape(uri.Password, passwordUnreserved))
+		builder.WriteString("@")
+	}
+
+	builder.WriteString("[")
+	builder.WriteString(canonicalHost(uri.Host))
+	builder.WriteString("]:")
+	builder.WriteString(strconv.Itoa(uri.Port))
+
+	parameters := canonicalParameters(uri.Parameters)
+
+	for _, name := range significantParameters {
+		if value, ok := parameters[name]; ok {
+			builder.WriteString(";")
+			builder.WriteString(name)
+			builder.WriteString("=")
+			builder.WriteString(escape(strings.ToLower(value), paramUnreserved))
+		}
+	}
+
+	headers := make([]string, 0, len(uri.Headers))
+
+	for header := range uri.Headers {
+		headers = append(headers, header)
+	}
+
+	sort.Strings(headers)
+
+	for index, header := range headers {
+		if index == 0 {
+			builder.WriteString("?")
+		} else {
+			builder.WriteString("&")
+		}
+
+		builder.WriteString(escape(header, headerUnreserved))
+		builder.WriteString("=")
+		builder.WriteString(escape(uri.Headers[header], headerUnreserved))
+	}
+
+	return builder.String()
+}
+
+// canonicalHost returns host in lower case, with IPv6 addresses in their shortest form, so that "2001:DB8:0::1" and
+// "2001:db8::1" compare equal.
+func canonicalHost(host string) string {
+	if strings.Contains(host, ":") {
+		if address := net.ParseIP(host); address != nil && address.To4() == nil {
+			return address.String()
+		}
+	}
+
+	return strings.ToLower(host)
+}
+
+// canonicalParameters returns a copy of parameters with their names in lower case.
+func canonicalParameters(parameters map[string]string) map[string]string {
+	canonical := make(map[string]string, len(parameters))
+
+	for name, value := range parameters {
+		canonical[strings.ToLower(name)] = value
+	}
+
+	return canonical
+}
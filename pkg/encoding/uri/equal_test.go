@@ -0,0 +1,159 @@
+package uri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{
+			name:  "Escaped user and case of host and parameters",
+			a:     "sip:%61lice@atlanta.com;transport=TCP",
+			b:     "sip:alice@AtLanTa.CoM;Transport=tcp",
+			equal: true,
+		},
+		{
+			name:  "Parameter present in only one URI",
+			a:     "sip:carol@chicago.com",
+			b:     "sip:carol@chicago.com;newparam=5",
+			equal: true,
+		},
+		{
+			name:  "Different parameters present in only one URI each",
+			a:     "sip:carol@chicago.com;security=on",
+			b:     "sip:carol@chicago.com;newparam=5",
+			equal: true,
+		},
+		{
+			name:  "Order of parameters and headers",
+			a:     "sip:biloxi.com;transport=tcp;method=REGISTER?to=sip:bob%40biloxi.com",
+			b:     "sip:biloxi.com;method=REGISTER;transport=tcp?to=sip:bob%40biloxi.com",
+			equal: true,
+		},
+		{
+			name:  "Order of headers",
+			a:     "sip:alice@atlanta.com?subject=project%20x&priority=urgent",
+			b:     "sip:alice@atlanta.com?priority=urgent&subject=project%20x",
+			equal: true,
+		},
+		{
+			name:  "IPv6 addresses in different forms",
+			a:     "sip:alice@[2001:DB8:0::1]:5060",
+			b:     "sip:alice@[2001:db8::1]:5060",
+			equal: true,
+		},
+		{
+			name: "Case of user",
+			a:    "SIP:ALICE@AtLanTa.CoM;Transport=udp",
+			b:    "sip:alice@AtLanTa.CoM;Transport=UDP",
+		},
+		{
+			name: "Case of password",
+			a:    "sip:alice:Secret@atlanta.com",
+			b:    "sip:alice:secret@atlanta.com",
+		},
+		{
+			name: "SIP and SIPS schemes",
+			a:    "sip:alice@atlanta.com",
+			b:    "sips:alice@atlanta.com",
+		},
+		{
+			name: "Port present in only one URI",
+			a:    "sip:bob@biloxi.com",
+			b:    "sip:bob@biloxi.com:5060",
+		},
+		{
+			name: "Transport present in only one URI",
+			a:    "sip:bob@biloxi.com",
+			b:    "sip:bob@biloxi.com;transport=udp",
+		},
+		{
+			name: "Port and transport present in only one URI",
+			a:    "sip:bob@biloxi.com",
+			b:    "sip:bob@biloxi.com:6000;transport=tcp",
+		},
+		{
+			name: "User parameter present in only one URI",
+			a:    "sip:+12125551212@phone2net.com",
+			b:    "sip:+12125551212@phone2net.com;user=phone",
+		},
+		{
+			name: "Maddr present in only one URI",
+			a:    "sip:carol@chicago.com",
+			b:    "sip:carol@chicago.com;maddr=239.255.255.1",
+		},
+		{
+			name: "Parameter present in both URIs with different values",
+			a:    "sip:carol@chicago.com;security=on",
+			b:    "sip:carol@chicago.com;security=off",
+		},
+		{
+			name: "Header present in only one URI",
+			a:    "sip:carol@chicago.com",
+			b:    "sip:carol@chicago.com?Subject=next%20meeting",
+		},
+		{
+			name: "Case of header value",
+			a:    "sip:carol@chicago.com?Subject=next%20meeting",
+			b:    "sip:carol@chicago.com?Subject=Next%20Meeting",
+		},
+		{
+			name: "Domain name and IP address",
+			a:    "sip:bob@phone21.boxesbybob.com",
+			b:    "sip:bob@192.0.2.4",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := new(URI), new(URI)
+
+			assert.NoError(t, Unmarshal(tc.a, a))
+			assert.NoError(t, Unmarshal(tc.b, b))
+
+			assert.Equal(t, tc.equal, Equal(a, b))
+			assert.Equal(t, tc.equal, Equal(b, a))
+
+			if tc.equal {
+				assert.Equal(t, Key(a), Key(b))
+			}
+		})
+	}
+}
+
+func TestEqualWithNil(t *testing.T) {
+	value := &URI{Scheme: "sip", Host: "example.com"}
+
+	assert.True(t, Equal(nil, nil))
+	assert.False(t, Equal(value, nil))
+	assert.False(t, Equal(nil, value))
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    string
+	}{
+		{payload: "sip:example.com", want: "sip:[example.com]:0"},
+		{payload: "SIP:%61lice@AtLanTa.CoM:5060;Transport=TCP;lr", want: "sip:alice:@[atlanta.com]:5060;transport=tcp"},
+		{payload: "sips:bob:pw@[2001:DB8::1];user=phone;ttl=1?b=2&a=1", want: "sips:bob:pw@[2001:db8::1]:0;ttl=1;user=phone?a=1&b=2"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.payload, func(t *testing.T) {
+			value := new(URI)
+
+			assert.NoError(t, Unmarshal(tc.payload, value))
+			assert.Equal(t, tc.want, Key(value))
+		})
+	}
+
+	assert.Equal(t, "", Key(nil))
+}
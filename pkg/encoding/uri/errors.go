@@ -4,3 +4,36 @@ import "fmt"
 
 // ErrInvalidSipURI when we have an invalid SIP URI.
 var ErrInvalidSipURI = fmt.Errorf("invalid SIP URI")
+
+// ErrInvalidTelURI when we have an invalid tel URI.
+var ErrInvalidTelURI = fmt.Errorf("invalid tel URI")
+
+// ErrInvalidURN when we have an invalid URN.
+var ErrInvalidURN = fmt.Errorf("invalid URN")
+
+// ErrInvalidURI when we have an invalid URI of a scheme without a dedicated type.
+var ErrInvalidURI = fmt.Errorf("invalid URI")
+
+// URIError describes why a URI could not be parsed.
+//
+// It wraps ErrInvalidSipURI, ErrInvalidTelURI, ErrInvalidURN or ErrInvalidURI, so errors.Is keeps working on it.
+type URIError struct {
+	// URI is the payload that could not be parsed.
+	URI string
+
+	// Reason describes the problem.
+	Reason string
+
+	// Err is the sentinel error that classifies the problem, usually ErrInvalidSipURI or ErrInvalidTelURI.
+	Err error
+}
+
+// Error returns the description of the problem, along with the offending payload.
+func (err *URIError) Error() string {
+	return fmt.Sprintf("%s %q: %s", err.Err, err.URI, err.Reason)
+}
+
+// Unwrap returns the sentinel error that classifies the problem.
+func (err *URIError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,136 @@
+package uri
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/internal/grammar"
+)
+
+// The characters that each component of a SIP URI may hold unescaped besides the unreserved characters, as defined
+// by the user-unreserved, password, param-unreserved and hnv-unreserved rules of RFC 3261 section 25.1.
+const (
+	userUnreserved     = "&=+$,;?/"
+	passwordUnreserved = "&=+$,"
+	paramUnreserved    = "[]/:&+$"
+	headerUnreserved   = "[]/?:+$"
+)
+
+// upperhex holds the digits used to escape an octet.
+const upperhex = "0123456789ABCDEF"
+
+// isUnreserved reports whether char is an unreserved character, that is an alphanumeric character or a mark, as
+// defined in RFC 3261 section 25.1.
+func isUnreserved(char byte) bool {
+	switch {
+	case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		return true
+	}
+
+	return strings.IndexByte("-_.!~*'()", char) >= 0
+}
+
+// escape returns value with every octet that is neither unreserved nor in allowed written as an escape, such as
+// "%40" for "@".
+func escape(value string, allowed string) string {
+	var builder strings.Builder
+
+	for index := 0; index < len(value); index++ {
+		char := value[index]
+
+		if isUnreserved(char) || strings.IndexByte(allowed, char) >= 0 {
+			builder.WriteByte(char)
+
+			continue
+		}
+
+		builder.WriteByte('%')
+		builder.WriteByte(upperhex[char>>4])
+		builder.WriteByte(upperhex[char&0x0f])
+	}
+
+	return builder.String()
+}
+
+// unescape returns value with its escapes replaced by the octets they stand for. It returns the reason why value is
+// invalid if one of its escapes is not a "%" followed by two hexadecimal digits.
+func unescape(value string) (string, string) {
+	if !strings.Contains(value, "%") {
+		return value, ""
+	}
+
+	var builder strings.Builder
+
+	for index := 0; index < len(value); index++ {
+		char := value[index]
+
+		if char != '%' {
+			builder.WriteByte(char)
+
+			continue
+		}
+
+		if index+2 >= len(value) || !grammar.IsHex(value[index+1]) || !grammar.IsHex(value[index+2]) {
+			return "", fmt.Sprintf("invalid escape in %q", value)
+		}
+
+		builder.WriteByte(unhex(value[index+1])<<4 | unhex(value[index+2]))
+
+		index += 2
+	}
+
+	return builder.String(), ""
+}
+
+// unhex returns the value of the hexadecimal digit char.
+func unhex(char byte) byte {
+	switch {
+	case char >= 'a':
+		return char - 'a' + 10
+	case char >= 'A':
+		return char - 'A' + 10
+	}
+
+	return char - '0'
+}
+
+// unescapeComponents replaces the escapes of the user, password, parameters and headers of uri, returning the reason
+// why one of them is invalid, if it is.
+func unescapeComponents(uri *URI) string {
+	var reason string
+
+	if uri.User, reason = unescape(uri.User); reason != "" {
+		return reason
+	}
+
+	if uri.Password, reason = unescape(uri.Password); reason != "" {
+		return reason
+	}
+
+	if uri.Parameters, reason = unescapeMap(uri.Parameters); reason != "" {
+		return reason
+	}
+
+	uri.Headers, reason = unescapeMap(uri.Headers)
+
+	return reason
+}
+
+// unescapeMap returns a copy of fields with the escapes of its names and values replaced.
+func unescapeMap(fields map[string]string) (map[string]string, string) {
+	unescaped := make(map[string]string, len(fields))
+
+	for name, value := range fields {
+		name, reason := unescape(name)
+
+		if reason != "" {
+			return nil, reason
+		}
+
+		if unescaped[name], reason = unescape(value); reason != "" {
+			return nil, reason
+		}
+	}
+
+	return unescaped, ""
+}
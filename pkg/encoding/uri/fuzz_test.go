@@ -0,0 +1,48 @@
+package uri
+
+import (
+	"testing"
+)
+
+func FuzzUnmarshal(f *testing.F) {
+	for _, payload := range readTestdata(f, "valid.txt") {
+		f.Add(payload)
+	}
+
+	for _, payload := range readTestdata(f, "invalid.txt") {
+		f.Add(payload)
+	}
+
+	f.Add("")
+	f.Add("sips:user:password@example.com:5061;transport=udp?header1=value1&header2=value2")
+
+	f.Fuzz(func(t *testing.T, payload string) {
+		uri := new(URI)
+
+		if err := Unmarshal(payload, uri); err != nil {
+			return
+		}
+
+		encoded, err := Marshal(uri)
+
+		if err != nil {
+			t.Fatalf("failed to marshal an unmarshaled URI: %v", err)
+		}
+
+		again := new(URI)
+
+		if err := Unmarshal(encoded, again); err != nil {
+			t.Fatalf("failed to unmarshal a marshaled URI: %v\n%q", err, encoded)
+		}
+
+		reencoded, err := Marshal(again)
+
+		if err != nil {
+			t.Fatalf("failed to marshal a URI for the second time: %v", err)
+		}
+
+		if encoded != reencoded {
+			t.Fatalf("marshal did not reach a fixed point:\n%q\n%q", encoded, reencoded)
+		}
+	})
+}
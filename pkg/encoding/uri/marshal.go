@@ -7,27 +7,43 @@ import (
 )
 
 // Marshal takes a URI struct and converts it to its string representation.
-// It returns an error if the URI is invalid or nil.
+// The user, password, parameters and headers are escaped as needed by the grammar of RFC 3261 section 25.1.
+// It returns an error if the URI is nil, is not a sip or sips URI, or has no host.
 func Marshal(uri *URI) (string, error) {
 	if uri == nil {
 		return "", ErrInvalidSipURI
 	}
 
+	if scheme := strings.ToLower(uri.Scheme); scheme != "sip" && scheme != "sips" {
+		return "", &URIError{URI: uri.address(), Reason: "scheme is not sip or sips", Err: ErrInvalidSipURI}
+	}
+
+	if uri.Host == "" {
+		return "", &URIError{URI: uri.address(), Reason: "missing host", Err: ErrInvalidSipURI}
+	}
+
 	var builder strings.Builder
 
 	builder.WriteString(uri.Scheme)
 	builder.WriteString(":")
 
 	if uri.User != "" {
-		builder.WriteString(uri.User)
+		builder.WriteString(escape(uri.User, userUnreserved))
 		if uri.Password != "" {
 			builder.WriteString(":")
-			builder.WriteString(uri.Password)
+			builder.WriteString(escape(uri.Password, passwordUnreserved))
 		}
 		builder.WriteString("@")
 	}
 
-	builder.WriteString(uri.Host)
+	if strings.Contains(uri.Host, ":") && !strings.HasPrefix(uri.Host, "[") {
+		// An IPv6 address is written as a reference, as defined in RFC 3261 section 25.1
+		builder.WriteString("[")
+		builder.WriteString(uri.Host)
+		builder.WriteString("]")
+	} else {
+		builder.WriteString(uri.Host)
+	}
 
 	if uri.Port != 0 {
 		builder.WriteString(":")
@@ -42,11 +58,11 @@ func Marshal(uri *URI) (string, error) {
 
 	for _, param := range sortedParams {
 		builder.WriteString(";")
-		builder.WriteString(param)
+		builder.WriteString(escape(param, paramUnreserved))
 		value := uri.Parameters[param]
 		if value != "" {
 			builder.WriteString("=")
-			builder.WriteString(value)
+			builder.WriteString(escape(value, paramUnreserved))
 		}
 	}
 
@@ -62,11 +78,11 @@ func Marshal(uri *URI) (string, error) {
 		} else {
 			builder.WriteString("&")
 		}
-		builder.WriteString(header)
+		builder.WriteString(escape(header, headerUnreserved))
 		value := uri.Headers[header]
 		if value != "" {
 			builder.WriteString("=")
-			builder.WriteString(value)
+			builder.WriteString(escape(value, headerUnreserved))
 		}
 	}
 
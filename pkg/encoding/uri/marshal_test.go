@@ -47,6 +47,39 @@ func TestMarshalWithValidCases(t *testing.T) {
 			},
 			expected: "sip:user@example.com;transport=tcp?header1=value1",
 		},
+		{
+			name: "IPv6 host with port",
+			uri: &URI{
+				Scheme: "sip",
+				User:   "alice",
+				Host:   "2001:db8::1",
+				Port:   5060,
+			},
+			expected: "sip:alice@[2001:db8::1]:5060",
+		},
+		{
+			name: "URI with components that need escaping",
+			uri: &URI{
+				Scheme:     "sip",
+				User:       "alice@corp",
+				Password:   "p:ss word",
+				Host:       "example.com",
+				Parameters: map[string]string{"x-id": "a;b=c"},
+				Headers:    map[string]string{"subject": "fish & chips = 100%"},
+			},
+			expected: "sip:alice%40corp:p%3Ass%20word@example.com;x-id=a%3Bb%3Dc?subject=fish%20%26%20chips%20%3D%20100%25",
+		},
+		{
+			name: "URI with reserved characters allowed in each component",
+			uri: &URI{
+				Scheme:     "sip",
+				User:       "+1;ext=2?/",
+				Host:       "example.com",
+				Parameters: map[string]string{"maddr": "[2001:db8::1]"},
+				Headers:    map[string]string{"route": "<sip:proxy.example.com;lr>"},
+			},
+			expected: "sip:+1;ext=2?/@example.com;maddr=[2001:db8::1]?route=%3Csip:proxy.example.com%3Blr%3E",
+		},
 		{
 			name: "URI with empty parameter value",
 			uri: &URI{
@@ -86,6 +119,26 @@ func TestMarshalWithValidCases(t *testing.T) {
 	}
 }
 
+func TestMarshalRoundTripsEscapedComponents(t *testing.T) {
+	want := &URI{
+		Scheme:     "sip",
+		User:       "alice%40corp",
+		Password:   "a:b@c",
+		Host:       "example.com",
+		Parameters: map[string]string{"tag": "x y", "p%": ""},
+		Headers:    map[string]string{"h&=": "&=?%"},
+	}
+
+	encoded, err := Marshal(want)
+
+	assert.NoError(t, err)
+
+	got := new(URI)
+
+	assert.NoError(t, Unmarshal(encoded, got))
+	assert.Equal(t, want, got)
+}
+
 func TestMarshalWithInvalidCases(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -97,6 +150,21 @@ func TestMarshalWithInvalidCases(t *testing.T) {
 			uri:      nil,
 			expected: ErrInvalidSipURI,
 		},
+		{
+			name:     "Empty URI should return error",
+			uri:      &URI{},
+			expected: ErrInvalidSipURI,
+		},
+		{
+			name:     "URI of another scheme should return error",
+			uri:      &URI{Scheme: "mailto", User: "alice", Host: "atlanta.com"},
+			expected: ErrInvalidSipURI,
+		},
+		{
+			name:     "URI without host should return error",
+			uri:      &URI{Scheme: "sip", User: "alice"},
+			expected: ErrInvalidSipURI,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -107,3 +175,24 @@ func TestMarshalWithInvalidCases(t *testing.T) {
 		})
 	}
 }
+
+func TestStringWithInvalidURIs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		uri      AbsoluteURI
+		expected string
+	}{
+		{name: "Empty SIP URI", uri: &URI{}, expected: "sip:"},
+		{name: "SIP URI without host", uri: &URI{Scheme: "sips", User: "alice"}, expected: "sips:alice@"},
+		{name: "Empty tel URI", uri: &TelURI{}, expected: "tel:"},
+		{name: "Tel URI with an invalid number", uri: &TelURI{Number: "+1-555-CALL"}, expected: "tel:+1-555-CALL"},
+		{name: "Nil SIP URI", uri: (*URI)(nil), expected: ""},
+		{name: "Nil tel URI", uri: (*TelURI)(nil), expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.uri.String())
+		})
+	}
+}
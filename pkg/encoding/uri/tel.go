@@ -0,0 +1,385 @@
+package uri
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/internal/grammar"
+)
+
+// TelURI is a struct that represents a tel URI, as defined in RFC 3966, such as
+// "tel:+1-555-123-4567;ext=22" or "tel:7042;phone-context=example.com".
+type TelURI struct {
+	// Number is the telephone number as written, with its visual separators and without escapes, such as
+	// "+1-555-123-4567" or "*21#". A global number starts with "+", and a local number must have a PhoneContext.
+	Number string
+
+	// PhoneContext is the phone-context parameter, a domain name or a global number prefix in which a local number
+	// is valid.
+	PhoneContext string
+
+	// Extension is the ext parameter, which may be empty.
+	Extension string
+
+	// Subaddress is the isub parameter, an ISDN subaddress, which may be empty.
+	Subaddress string
+
+	// Parameters is a map of the other parameters, where the key is the parameter name and the value is the
+	// parameter value.
+	Parameters map[string]string
+}
+
+// IsGlobal reports whether tel holds a global number, that is a number in E.164 form starting with "+".
+func (tel *TelURI) IsGlobal() bool {
+	return strings.HasPrefix(tel.Number, "+")
+}
+
+// Digits returns the number of tel without its visual separators, such as "+15551234567" for "+1-555-123-4567".
+func (tel *TelURI) Digits() string {
+	return stripVisualSeparators(tel.Number)
+}
+
+// SchemeName returns "tel".
+func (tel *TelURI) SchemeName() string {
+	return "tel"
+}
+
+// String returns the string representation of tel, as written by MarshalTel, or only its scheme and number when tel
+// is invalid, as URI.String does with its user and host.
+func (tel *TelURI) String() string {
+	if tel == nil {
+		return ""
+	}
+
+	encoded, err := MarshalTel(tel)
+
+	if err != nil {
+		return "tel:" + tel.Number
+	}
+
+	return encoded
+}
+
+// UnmarshalTel takes a string payload and a pointer to a TelURI struct.
+// It parses the string representation of a tel URI into the TelURI struct, replacing the escapes of the number and
+// parameters, such as "%23" for "#", by the characters they stand for. A "#" written without escape is accepted.
+// It returns a *URIError that wraps ErrInvalidTelURI if the URI is invalid or the pointer is nil.
+func UnmarshalTel(payload string, tel *TelURI) error {
+	if tel == nil {
+		return &URIError{URI: payload, Reason: "nil destination", Err: ErrInvalidTelURI}
+	}
+
+	scheme, subscriber, found := strings.Cut(payload, ":")
+
+	if !found || !strings.EqualFold(scheme, "tel") {
+		return &URIError{URI: payload, Reason: "scheme is not tel", Err: ErrInvalidTelURI}
+	}
+
+	if reason := unmarshalSubscriber(subscriber, tel); reason != "" {
+		return &URIError{URI: payload, Reason: reason, Err: ErrInvalidTelURI}
+	}
+
+	return nil
+}
+
+// MarshalTel takes a TelURI struct and converts it to its string representation.
+// The "#" of the number is written as "%23", and the isub and ext parameters are written first, followed by
+// phone-context and the other parameters in lexicographical order, as required by RFC 3966 section 3.
+func MarshalTel(tel *TelURI) (string, error) {
+	if tel == nil {
+		return "", ErrInvalidTelURI
+	}
+
+	subscriber, reason := marshalSubscriber(tel, true)
+
+	if reason != "" {
+		return "", &URIError{URI: tel.Number, Reason: reason, Err: ErrInvalidTelURI}
+	}
+
+	return "tel:" + subscriber, nil
+}
+
+// TelToSIP converts tel into a SIP URI for host, with the telephone number and its parameters as the user and
+// a user=phone parameter, as described in RFC 3261 section 19.1.6.
+func TelToSIP(tel *TelURI, host string) (*URI, error) {
+	if tel == nil {
+		return nil, ErrInvalidTelURI
+	}
+
+	// The number is left unescaped, since Marshal escapes the user of a SIP URI
+	subscriber, reason := marshalSubscriber(tel, false)
+
+	if reason != "" {
+		return nil, &URIError{URI: tel.Number, Reason: reason, Err: ErrInvalidTelURI}
+	}
+
+	return &URI{
+		Scheme:     "sip",
+		User:       subscriber,
+		Host:       host,
+		Parameters: map[string]string{"user": "phone"},
+		Headers:    map[string]string{},
+	}, nil
+}
+
+// SIPToTel converts a SIP or SIPS URI with a user=phone parameter into a tel URI, parsing its user as a telephone
+// number, as described in RFC 3261 section 19.1.6.
+func SIPToTel(uri *URI) (*TelURI, error) {
+	if uri == nil {
+		return nil, ErrInvalidTelURI
+	}
+
+	payload := uri.String()
+
+	if scheme := strings.ToLower(uri.Scheme); scheme != "sip" && scheme != "sips" {
+		return nil, &URIError{URI: payload, Reason: "scheme is not sip or sips", Err: ErrInvalidTelURI}
+	}
+
+	if !strings.EqualFold(canonicalParameters(uri.Parameters)["user"], "phone") {
+		return nil, &URIError{URI: payload, Reason: "missing user=phone parameter", Err: ErrInvalidTelURI}
+	}
+
+	tel := new(TelURI)
+
+	if reason := unmarshalSubscriber(uri.User, tel); reason != "" {
+		return nil, &URIError{URI: payload, Reason: reason, Err: ErrInvalidTelURI}
+	}
+
+	return tel, nil
+}
+
+// unmarshalSubscriber parses a telephone-subscriber, that is a number followed by its parameters, into tel,
+// returning the reason why it is invalid, if it is.
+func unmarshalSubscriber(subscriber string, tel *TelURI) string {
+	fields := strings.Split(subscriber, ";")
+
+	number, reason := unescape(fields[0])
+
+	if reason != "" {
+		return reason
+	}
+
+	tel.Number = number
+	tel.PhoneContext = ""
+	tel.Extension = ""
+	tel.Subaddress = ""
+	tel.Parameters = make(map[string]string)
+
+	seen := make(map[string]bool)
+
+	for _, field := range fields[1:] {
+		name, value, found := strings.Cut(field, "=")
+		name = strings.ToLower(name)
+
+		if !isParameterName(name) {
+			return fmt.Sprintf("invalid parameter name %q", name)
+		}
+
+		if seen[name] {
+			return fmt.Sprintf("repeated parameter %q", name)
+		}
+
+		seen[name] = true
+
+		value, reason := unescape(value)
+
+		if reason != "" {
+			return reason
+		}
+
+		switch name {
+		case "phone-context":
+			if !found || !isGlobalNumber(value) && !isDomainName(value) {
+				return fmt.Sprintf("invalid phone-context %q", value)
+			}
+
+			tel.PhoneContext = value
+		case "ext":
+			if !found || !isPhoneDigits(value) {
+				return fmt.Sprintf("invalid extension %q", value)
+			}
+
+			tel.Extension = value
+		case "isub":
+			if !found || value == "" {
+				return "empty ISDN subaddress"
+			}
+
+			tel.Subaddress = value
+		default:
+			if found && value == "" {
+				return fmt.Sprintf("empty value of parameter %q", name)
+			}
+
+			tel.Parameters[name] = value
+		}
+	}
+
+	return checkNumber(tel)
+}
+
+// marshalSubscriber returns the telephone-subscriber of tel, or the reason why tel is invalid. When escaped is true,
+// the characters of the number that RFC 3966 reserves, such as "#", are written as escapes, such as "%23".
+func marshalSubscriber(tel *TelURI, escaped bool) (string, string) {
+	if reason := checkNumber(tel); reason != "" {
+		return "", reason
+	}
+
+	var builder strings.Builder
+
+	if escaped {
+		builder.WriteString(escape(tel.Number, "+"))
+	} else {
+		builder.WriteString(tel.Number)
+	}
+
+	if tel.Subaddress != "" {
+		builder.WriteString(";isub=")
+		builder.WriteString(escape(tel.Subaddress, paramUnreserved))
+	}
+
+	if tel.Extension != "" {
+		builder.WriteString(";ext=")
+		builder.WriteString(tel.Extension)
+	}
+
+	if tel.PhoneContext != "" {
+		builder.WriteString(";phone-context=")
+		builder.WriteString(tel.PhoneContext)
+	}
+
+	names := make([]string, 0, len(tel.Parameters))
+
+	for name := range tel.Parameters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		builder.WriteString(";")
+		builder.WriteString(name)
+
+		if value := tel.Parameters[name]; value != "" {
+			builder.WriteString("=")
+			builder.WriteString(escape(value, paramUnreserved))
+		}
+	}
+
+	return builder.String(), ""
+}
+
+// checkNumber returns the reason why the number of tel is invalid, if it is: a global number must have digits and
+// visual separators, and a local number hexadecimal digits, "*", "#" and visual separators, along with a
+// phone-context.
+func checkNumber(tel *TelURI) string {
+	if tel.IsGlobal() {
+		if !isGlobalNumber(tel.Number) {
+			return fmt.Sprintf("invalid global number %q", tel.Number)
+		}
+
+		return ""
+	}
+
+	if !isLocalNumber(tel.Number) {
+		return fmt.Sprintf("invalid local number %q", tel.Number)
+	}
+
+	if tel.PhoneContext == "" {
+		return "local number without phone-context"
+	}
+
+	return ""
+}
+
+// isVisualSeparator reports whether char is a visual separator, which only eases reading a number.
+func isVisualSeparator(char byte) bool {
+	return char == '-' || char == '.' || char == '(' || char == ')'
+}
+
+// stripVisualSeparators returns number without its visual separators.
+func stripVisualSeparators(number string) string {
+	var builder strings.Builder
+
+	for index := 0; index < len(number); index++ {
+		if char := number[index]; !isVisualSeparator(char) {
+			builder.WriteByte(char)
+		}
+	}
+
+	return builder.String()
+}
+
+// isGlobalNumber reports whether value is a "+" followed by digits and visual separators, with at least one digit.
+func isGlobalNumber(value string) bool {
+	return strings.HasPrefix(value, "+") && isPhoneDigits(value[1:])
+}
+
+// isPhoneDigits reports whether value only has digits and visual separators, with at least one digit.
+func isPhoneDigits(value string) bool {
+	digits := stripVisualSeparators(value)
+
+	if digits == "" {
+		return false
+	}
+
+	for index := 0; index < len(digits); index++ {
+		if char := digits[index]; char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isLocalNumber reports whether value only has hexadecimal digits, "*", "#" and visual separators, with at least
+// one character other than a visual separator.
+func isLocalNumber(value string) bool {
+	digits := stripVisualSeparators(value)
+
+	if digits == "" {
+		return false
+	}
+
+	for index := 0; index < len(digits); index++ {
+		if char := digits[index]; !grammar.IsHex(char) && char != '*' && char != '#' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isParameterName reports whether name is the name of a tel URI parameter, made of alphanumeric characters and "-".
+func isParameterName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for index := 0; index < len(name); index++ {
+		if char := name[index]; !isUnreserved(char) || strings.IndexByte("_.!~*'()", char) >= 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDomainName reports whether value is a domain name, made of labels of alphanumeric characters and "-" separated
+// by dots, with an optional trailing dot.
+func isDomainName(value string) bool {
+	value = strings.TrimSuffix(value, ".")
+
+	if value == "" {
+		return false
+	}
+
+	for _, label := range strings.Split(value, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") || !isParameterName(label) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,273 @@
+package uri
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalTelWithValidCases(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *TelURI
+	}{
+		{
+			name:    "Test global number",
+			payload: "tel:+15551234567",
+			want:    &TelURI{Number: "+15551234567", Parameters: map[string]string{}},
+		},
+		{
+			name:    "Test global number with visual separators",
+			payload: "tel:+1-(555).123-4567",
+			want:    &TelURI{Number: "+1-(555).123-4567", Parameters: map[string]string{}},
+		},
+		{
+			name:    "Test local number with domain name context",
+			payload: "tel:7042;phone-context=example.com",
+			want:    &TelURI{Number: "7042", PhoneContext: "example.com", Parameters: map[string]string{}},
+		},
+		{
+			name:    "Test local number with global number context",
+			payload: "tel:863-1234;phone-context=+1-914-555",
+			want:    &TelURI{Number: "863-1234", PhoneContext: "+1-914-555", Parameters: map[string]string{}},
+		},
+		{
+			name:    "Test local number with hexadecimal digits, star and hash",
+			payload: "TEL:*67#a;phone-context=example.com",
+			want:    &TelURI{Number: "*67#a", PhoneContext: "example.com", Parameters: map[string]string{}},
+		},
+		{
+			name:    "Test local number with an escaped hash",
+			payload: "tel:*21%23;phone-context=example.com",
+			want:    &TelURI{Number: "*21#", PhoneContext: "example.com", Parameters: map[string]string{}},
+		},
+		{
+			name:    "Test extension, subaddress and other parameters",
+			payload: "tel:+1-201-555-0123;ext=22;isub=1411%2F1;Foo=b%61r;baz",
+			want: &TelURI{
+				Number:     "+1-201-555-0123",
+				Extension:  "22",
+				Subaddress: "1411/1",
+				Parameters: map[string]string{"foo": "bar", "baz": ""},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := new(TelURI)
+
+			assert.NoError(t, UnmarshalTel(tc.payload, got))
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestUnmarshalTelWithInvalidCases(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		tel     *TelURI
+	}{
+		{name: "Test without TelURI instance", payload: "tel:+15551234567"},
+		{name: "Test SIP scheme", payload: "sip:+15551234567@example.com", tel: new(TelURI)},
+		{name: "Test missing scheme", payload: "+15551234567", tel: new(TelURI)},
+		{name: "Test empty number", payload: "tel:", tel: new(TelURI)},
+		{name: "Test global number without digits", payload: "tel:+--", tel: new(TelURI)},
+		{name: "Test global number with letters", payload: "tel:+1555CALLNOW", tel: new(TelURI)},
+		{name: "Test local number without phone-context", payload: "tel:7042", tel: new(TelURI)},
+		{name: "Test invalid phone-context", payload: "tel:7042;phone-context=exa_mple.com", tel: new(TelURI)},
+		{name: "Test extension with letters", payload: "tel:+15551234567;ext=abc", tel: new(TelURI)},
+		{name: "Test empty subaddress", payload: "tel:+15551234567;isub=", tel: new(TelURI)},
+		{name: "Test repeated parameter", payload: "tel:+15551234567;ext=1;EXT=2", tel: new(TelURI)},
+		{name: "Test invalid parameter name", payload: "tel:+15551234567;f_o=1", tel: new(TelURI)},
+		{name: "Test invalid escape", payload: "tel:+15551234567;foo=%zz", tel: new(TelURI)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := UnmarshalTel(tc.payload, tc.tel)
+
+			assert.ErrorIs(t, err, ErrInvalidTelURI)
+
+			var uriError *URIError
+
+			assert.True(t, errors.As(err, &uriError))
+			assert.Equal(t, tc.payload, uriError.URI)
+		})
+	}
+}
+
+func TestMarshalTel(t *testing.T) {
+	tests := []struct {
+		name string
+		tel  *TelURI
+		want string
+	}{
+		{
+			name: "Global number",
+			tel:  &TelURI{Number: "+1-555-123-4567"},
+			want: "tel:+1-555-123-4567",
+		},
+		{
+			name: "Parameters in the order of RFC 3966",
+			tel: &TelURI{
+				Number:       "7042",
+				PhoneContext: "example.com",
+				Extension:    "22",
+				Subaddress:   "1411/1",
+				Parameters:   map[string]string{"zeta": "1", "alpha": "a;b", "flag": ""},
+			},
+			want: "tel:7042;isub=1411/1;ext=22;phone-context=example.com;alpha=a%3Bb;flag;zeta=1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := MarshalTel(tc.tel)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+
+			again := new(TelURI)
+
+			assert.NoError(t, UnmarshalTel(got, again))
+			assert.Equal(t, tc.tel.Number, again.Number)
+		})
+	}
+}
+
+func TestTelURIRoundTripsTheHash(t *testing.T) {
+	for _, payload := range []string{"tel:*21%23;phone-context=example.com", "tel:*21#;phone-context=example.com"} {
+		t.Run(payload, func(t *testing.T) {
+			got := new(TelURI)
+
+			assert.NoError(t, UnmarshalTel(payload, got))
+			assert.Equal(t, "*21#", got.Number)
+
+			encoded, err := MarshalTel(got)
+
+			assert.NoError(t, err)
+			assert.Equal(t, "tel:*21%23;phone-context=example.com", encoded)
+
+			again := new(TelURI)
+
+			assert.NoError(t, UnmarshalTel(encoded, again))
+			assert.Equal(t, got, again)
+		})
+	}
+
+	t.Run("through a SIP URI", func(t *testing.T) {
+		tel := &TelURI{Number: "*21#", PhoneContext: "example.com", Parameters: map[string]string{}}
+
+		sip, err := TelToSIP(tel, "gateway.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "sip:*21%23;phone-context=example.com@gateway.com;user=phone", sip.String())
+
+		parsed := new(URI)
+
+		assert.NoError(t, Unmarshal(sip.String(), parsed))
+
+		back, err := SIPToTel(parsed)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tel, back)
+	})
+}
+
+func TestMarshalTelWithInvalidCases(t *testing.T) {
+	for _, tel := range []*TelURI{nil, {Number: "7042"}, {Number: "+1-555-CALL"}} {
+		_, err := MarshalTel(tel)
+
+		assert.ErrorIs(t, err, ErrInvalidTelURI)
+	}
+}
+
+func TestTelURIDigits(t *testing.T) {
+	tel := &TelURI{Number: "+1-(555).123-4567"}
+
+	assert.True(t, tel.IsGlobal())
+	assert.Equal(t, "+15551234567", tel.Digits())
+
+	local := &TelURI{Number: "863-1234", PhoneContext: "+1-914-555"}
+
+	assert.False(t, local.IsGlobal())
+	assert.Equal(t, "8631234", local.Digits())
+}
+
+func TestTelToSIP(t *testing.T) {
+	tel := new(TelURI)
+
+	assert.NoError(t, UnmarshalTel("tel:+358-555-1234567;postd=pp22", tel))
+
+	got, err := TelToSIP(tel, "foo.com")
+
+	assert.NoError(t, err)
+
+	encoded, err := Marshal(got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sip:+358-555-1234567;postd=pp22@foo.com;user=phone", encoded)
+
+	back, err := SIPToTel(got)
+
+	assert.NoError(t, err)
+	assert.Equal(t, tel, back)
+
+	_, err = TelToSIP(&TelURI{Number: "7042"}, "foo.com")
+
+	assert.ErrorIs(t, err, ErrInvalidTelURI)
+}
+
+func TestSIPToTel(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *TelURI
+	}{
+		{
+			name:    "Global number",
+			payload: "sip:+1-212-555-1212@gateway.com;user=phone",
+			want:    &TelURI{Number: "+1-212-555-1212", Parameters: map[string]string{}},
+		},
+		{
+			name:    "Local number with context and extension",
+			payload: "sips:7042;ext=22;phone-context=example.com@gateway.com;User=Phone",
+			want:    &TelURI{Number: "7042", Extension: "22", PhoneContext: "example.com", Parameters: map[string]string{}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value := new(URI)
+
+			assert.NoError(t, Unmarshal(tc.payload, value))
+
+			got, err := SIPToTel(value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	for _, payload := range []string{"sip:+12125551212@gateway.com", "sip:alice@atlanta.com;user=phone"} {
+		value := new(URI)
+
+		assert.NoError(t, Unmarshal(payload, value))
+
+		_, err := SIPToTel(value)
+
+		assert.ErrorIs(t, err, ErrInvalidTelURI, payload)
+	}
+
+	_, err := SIPToTel(&URI{Scheme: "mailto", User: "+12125551212", Host: "example.com", Parameters: map[string]string{"user": "phone"}})
+
+	assert.ErrorIs(t, err, ErrInvalidTelURI)
+
+	_, err = SIPToTel(nil)
+
+	assert.ErrorIs(t, err, ErrInvalidTelURI)
+}
@@ -0,0 +1,64 @@
+package uri
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readTestdata returns the lines of a testdata file, skipping blank lines and comments.
+func readTestdata(t testing.TB, name string) []string {
+	file, err := os.Open(filepath.Join("testdata", name))
+
+	assert.Nil(t, err)
+
+	defer file.Close()
+
+	var lines []string
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+
+	assert.Nil(t, scanner.Err())
+
+	return lines
+}
+
+func TestUnmarshalAcceptsValidTortureURIs(t *testing.T) {
+	for _, payload := range readTestdata(t, "valid.txt") {
+		t.Run(payload, func(t *testing.T) {
+			got := new(URI)
+
+			assert.Nil(t, Unmarshal(payload, got))
+
+			encoded, err := Marshal(got)
+
+			assert.Nil(t, err)
+
+			again := new(URI)
+
+			assert.Nil(t, Unmarshal(encoded, again))
+
+			assert.Equal(t, got, again)
+		})
+	}
+}
+
+func TestUnmarshalRejectsInvalidTortureURIs(t *testing.T) {
+	for _, payload := range readTestdata(t, "invalid.txt") {
+		t.Run(payload, func(t *testing.T) {
+			err := Unmarshal(payload, new(URI))
+
+			assert.ErrorIs(t, err, ErrInvalidSipURI)
+		})
+	}
+}
@@ -1,22 +1,38 @@
 package uri
 
 import (
+	"fmt"
+	"net"
 	"strconv"
 	"strings"
 )
 
 // Unmarshal takes a string payload and a pointer to a URI struct.
-// It parses the string representation of a SIP URI into the URI struct.
-// It returns an error if the URI is invalid or the pointer is nil.
+// It parses the string representation of a SIP URI into the URI struct, replacing the escapes of the user, password,
+// parameters and headers, such as "%40", by the octets they stand for.
+// It returns a *URIError if the URI is invalid, is not a sip or sips URI, or the pointer is nil. URIs of other
+// schemes, such as tel, are parsed by Parse.
 func Unmarshal(payload string, uri *URI) error {
 	if uri == nil {
-		return ErrInvalidSipURI
+		return &URIError{URI: payload, Reason: "nil destination", Err: ErrInvalidSipURI}
+	}
+
+	if strings.ContainsAny(payload, " \t\r\n") {
+		return &URIError{URI: payload, Reason: "whitespace is not allowed", Err: ErrInvalidSipURI}
 	}
 
 	indexOfColonOnPayload := strings.Index(payload, ":")
 
 	if indexOfColonOnPayload < 0 {
-		return ErrInvalidSipURI
+		return &URIError{URI: payload, Reason: "missing scheme", Err: ErrInvalidSipURI}
+	}
+
+	if !isScheme(payload[:indexOfColonOnPayload]) {
+		return &URIError{URI: payload, Reason: "invalid scheme", Err: ErrInvalidSipURI}
+	}
+
+	if scheme := strings.ToLower(payload[:indexOfColonOnPayload]); scheme != "sip" && scheme != "sips" {
+		return &URIError{URI: payload, Reason: fmt.Sprintf("unsupported scheme %q, use Parse", payload[:indexOfColonOnPayload]), Err: ErrInvalidSipURI}
 	}
 
 	uri.Scheme = payload[:indexOfColonOnPayload]
@@ -26,9 +42,13 @@ func Unmarshal(payload string, uri *URI) error {
 	if indexOfAtSymbolOnPayload < 0 {
 		uri.Host = payload[indexOfColonOnPayload+1:]
 	} else {
-		userpass := strings.Split(payload[indexOfColonOnPayload+1:indexOfColonOnPayload+1+indexOfAtSymbolOnPayload], ":")
+		userpass := strings.SplitN(payload[indexOfColonOnPayload+1:indexOfColonOnPayload+1+indexOfAtSymbolOnPayload], ":", 2)
 		uri.User = userpass[0]
 
+		if uri.User == "" {
+			return &URIError{URI: payload, Reason: "missing user before \"@\"", Err: ErrInvalidSipURI}
+		}
+
 		if len(userpass) > 1 {
 			uri.Password = userpass[1]
 		}
@@ -83,16 +103,103 @@ func Unmarshal(payload string, uri *URI) error {
 		}
 	}
 
-	if indexOfColonOnHost := strings.Index(uri.Host, ":"); indexOfColonOnHost >= 0 {
-		port, err := strconv.Atoi(uri.Host[indexOfColonOnHost+1:])
+	if reason := unescapeComponents(uri); reason != "" {
+		return &URIError{URI: payload, Reason: reason, Err: ErrInvalidSipURI}
+	}
 
-		if err != nil {
-			return ErrInvalidSipURI
-		}
+	host, port, reason := unmarshalHostPort(uri.Host)
 
-		uri.Host = uri.Host[:indexOfColonOnHost]
-		uri.Port = port
+	if reason != "" {
+		return &URIError{URI: payload, Reason: reason, Err: ErrInvalidSipURI}
 	}
 
+	uri.Host = host
+	uri.Port = port
+
 	return nil
 }
+
+// isScheme reports whether value is a valid URI scheme, as defined in RFC 3986 section 3.1.
+func isScheme(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for index, char := range value {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z':
+		case index > 0 && (char >= '0' && char <= '9' || char == '+' || char == '-' || char == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// unmarshalHostPort splits a hostport, such as "example.com:5060" or "[2001:db8::1]:5060", into its host and port,
+// removing the brackets of an IPv6 reference. It returns the reason why hostport is invalid, if it is.
+func unmarshalHostPort(hostport string) (string, int, string) {
+	host, port := hostport, ""
+
+	if strings.HasPrefix(hostport, "[") {
+		// The colons of an IPv6 reference are not port separators
+		end := strings.Index(hostport, "]")
+
+		if end < 0 {
+			return "", 0, "unterminated IPv6 reference"
+		}
+
+		host = hostport[1:end]
+
+		if address := net.ParseIP(host); address == nil || !strings.Contains(host, ":") {
+			return "", 0, fmt.Sprintf("invalid IPv6 reference %q", host)
+		}
+
+		switch rest := hostport[end+1:]; {
+		case rest == "":
+		case strings.HasPrefix(rest, ":"):
+			port = rest[1:]
+		default:
+			return "", 0, fmt.Sprintf("unexpected %q after IPv6 reference", rest)
+		}
+	} else if index := strings.Index(hostport, ":"); index >= 0 {
+		host, port = hostport[:index], hostport[index+1:]
+	}
+
+	if host == "" {
+		return "", 0, "missing host"
+	}
+
+	if isIPv4Form(host) && net.ParseIP(host).To4() == nil {
+		return "", 0, fmt.Sprintf("invalid IPv4 address %q", host)
+	}
+
+	if port == "" && !strings.HasSuffix(hostport, ":") {
+		return host, 0, ""
+	}
+
+	number, err := strconv.Atoi(port)
+
+	if err != nil || number < 0 || number > 65535 {
+		return "", 0, fmt.Sprintf("invalid port %q", port)
+	}
+
+	return host, number, ""
+}
+
+// isIPv4Form reports whether host only has digits and dots, with at least one dot. As the top label of a hostname
+// must start with a letter, such a host can only be an IPv4 address, as defined in RFC 3261 section 25.1.
+func isIPv4Form(host string) bool {
+	if !strings.Contains(host, ".") {
+		return false
+	}
+
+	for index := 0; index < len(host); index++ {
+		if char := host[index]; (char < '0' || char > '9') && char != '.' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,6 +1,7 @@
 package uri
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,6 +26,17 @@ func TestUnmarshalWithValidCases(t *testing.T) {
 				Headers:    map[string]string{},
 			},
 		},
+		{
+			name:    "Test IPv6 reference with port",
+			payload: "sip:[2001:db8::10]:5070",
+			expectedURI: &URI{
+				Scheme:     "sip",
+				Host:       "2001:db8::10",
+				Port:       5070,
+				Parameters: map[string]string{},
+				Headers:    map[string]string{},
+			},
+		},
 		{
 			name:    "Test full SIP URI",
 			payload: "sips:user:password@example.com:5061;transport=udp?header1=value1&header2=value2",
@@ -71,6 +83,22 @@ func TestUnmarshalWithValidCases(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "Test of SIP URI with escaped components",
+			payload: "sip:alice%40corp:p%3Ass@example.com;n%61me=v%61lue%25?subject=a%26b%3Dc",
+			expectedURI: &URI{
+				Scheme:   "sip",
+				User:     "alice@corp",
+				Password: "p:ss",
+				Host:     "example.com",
+				Parameters: map[string]string{
+					"name": "value%",
+				},
+				Headers: map[string]string{
+					"subject": "a&b=c",
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -110,6 +138,96 @@ func TestUnmarshalWithInvalidCases(t *testing.T) {
 			uri:         nil,
 			expectedErr: ErrInvalidSipURI,
 		},
+		{
+			name:        "Test whitespace inside URI",
+			payload:     " sip:t.watson@example.org ",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test invalid scheme",
+			payload:     "<sip:user@example.com>",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test tel URI",
+			payload:     "tel:+15551234567",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test http URI",
+			payload:     "http://example.com/path",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test unterminated IPv6 reference",
+			payload:     "sip:[2001:db8::10",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test invalid IPv6 reference",
+			payload:     "sip:user@[2001:db8:::192.0.2.1]",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test unbracketed IPv6 address",
+			payload:     "sip:user@2001:db8::10",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test garbage after IPv6 reference",
+			payload:     "sip:[2001:db8::10]5070",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test IPv4 address as IPv6 reference",
+			payload:     "sip:[192.0.2.1]",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test IPv4 address out of range",
+			payload:     "sip:alice@192.0.2.256:5060",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test truncated IPv4 address",
+			payload:     "sip:alice@192.0.2",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test truncated escape",
+			payload:     "sip:alice%4@example.com",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test escape with invalid digits",
+			payload:     "sip:example.com?subject=%zz",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test missing host",
+			payload:     "sip:alice@",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
+		{
+			name:        "Test missing user",
+			payload:     "sip::secret@example.com",
+			uri:         new(URI),
+			expectedErr: ErrInvalidSipURI,
+		},
 		{
 			name:        "Test invalid port",
 			payload:     "sip:example.com:invalid",
@@ -125,6 +243,87 @@ func TestUnmarshalWithInvalidCases(t *testing.T) {
 			err := Unmarshal(tc.payload, tc.uri)
 
 			assert.ErrorIs(t, err, tc.expectedErr)
+
+			var uriError *URIError
+
+			assert.True(t, errors.As(err, &uriError))
+			assert.Equal(t, tc.payload, uriError.URI)
+		})
+	}
+}
+
+// TestUnmarshalWithRFC5118Vectors checks the IPv6 references of the torture messages of RFC 5118.
+func TestUnmarshalWithRFC5118Vectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		user    string
+		host    string
+		port    int
+	}{
+		{
+			name:    "IPv6 reference",
+			payload: "sip:[2001:db8::10]",
+			host:    "2001:db8::10",
+		},
+		{
+			name:    "IPv6 reference with user",
+			payload: "sip:caller@[2001:db8::1]",
+			user:    "caller",
+			host:    "2001:db8::1",
+		},
+		{
+			name:    "port after IPv6 reference",
+			payload: "sip:[2001:db8::10]:5070",
+			host:    "2001:db8::10",
+			port:    5070,
+		},
+		{
+			name:    "ambiguous port inside IPv6 reference",
+			payload: "sip:[2001:db8::10:5070]",
+			host:    "2001:db8::10:5070",
+		},
+		{
+			name:    "IPv4-mapped IPv6 address",
+			payload: "sip:ted@[::ffff:192.0.2.2]",
+			user:    "ted",
+			host:    "::ffff:192.0.2.2",
+		},
+		{
+			name:    "IPv4 suffix in IPv6 reference",
+			payload: "sip:user@[2001:db8::192.0.2.1]",
+			user:    "user",
+			host:    "2001:db8::192.0.2.1",
+		},
+		{
+			name:    "IPv4 address with port",
+			payload: "sip:fluffy@192.0.2.1:5070",
+			user:    "fluffy",
+			host:    "192.0.2.1",
+			port:    5070,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := new(URI)
+
+			assert.Nil(t, Unmarshal(tc.payload, got))
+
+			assert.Equal(t, tc.user, got.User)
+			assert.Equal(t, tc.host, got.Host)
+			assert.Equal(t, tc.port, got.Port)
+
+			encoded, err := Marshal(got)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.payload, encoded)
 		})
 	}
 }
+
+func TestURIErrorMessage(t *testing.T) {
+	err := Unmarshal("sip:example.com:invalid", new(URI))
+
+	assert.EqualError(t, err, `invalid SIP URI "sip:example.com:invalid": invalid port "invalid"`)
+}
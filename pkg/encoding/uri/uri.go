@@ -1,7 +1,13 @@
 // Package uri provides a representation of a SIP (Session Initiation Protocol) URI
 // and functions for parsing and marshaling SIP URIs.
+//
+// It also handles the tel URIs of RFC 3966 with TelURI, which a gateway may convert to and from SIP URIs with a
+// user=phone parameter, and the URNs of RFC 8141 with URN. Parse returns any of them, or a GenericURI for other
+// schemes, behind the AbsoluteURI interface.
 package uri
 
+import "strings"
+
 // URI is a struct that represents a SIP URI and its components.
 type URI struct {
 	// Scheme is the URI scheme, such as "sip" or "sips".
@@ -13,7 +19,8 @@ type URI struct {
 	// Password is the password part of the URI, which may be empty.
 	Password string
 
-	// Host is the domain name or IP address of the SIP server.
+	// Host is the domain name or IP address of the SIP server. An IPv6 address is stored without the brackets of its
+	// reference, which Marshal restores.
 	Host string
 
 	// Port is the port number of the SIP server, which may be 0 if not specified.
@@ -27,3 +34,39 @@ type URI struct {
 	// Headers may include options such as "From", "To", "Call-ID", etc.
 	Headers map[string]string
 }
+
+// SchemeName returns the scheme of uri in lower case.
+func (uri *URI) SchemeName() string {
+	return strings.ToLower(uri.Scheme)
+}
+
+// String returns the string representation of uri, as written by Marshal, or only its scheme, user and host when uri
+// is invalid, as TelURI.String does with its number.
+func (uri *URI) String() string {
+	if uri == nil {
+		return ""
+	}
+
+	encoded, err := Marshal(uri)
+
+	if err != nil {
+		return uri.address()
+	}
+
+	return encoded
+}
+
+// address returns the scheme, user and host of uri without escapes, with "sip" as the scheme when it is empty.
+func (uri *URI) address() string {
+	scheme := uri.Scheme
+
+	if scheme == "" {
+		scheme = "sip"
+	}
+
+	if uri.User == "" {
+		return scheme + ":" + uri.Host
+	}
+
+	return scheme + ":" + uri.User + "@" + uri.Host
+}
@@ -0,0 +1,99 @@
+package uri
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otoru/party/pkg/encoding/internal/grammar"
+)
+
+// URN is a struct that represents a URN, as defined in RFC 8141, such as the "urn:service:sos" emergency service
+// URN of RFC 5031.
+type URN struct {
+	// NID is the namespace identifier, such as "service".
+	NID string
+
+	// NSS is the namespace specific string, such as "sos", along with its optional components.
+	NSS string
+}
+
+// SchemeName returns "urn".
+func (urn *URN) SchemeName() string {
+	return "urn"
+}
+
+// String returns the string representation of urn, as written by MarshalURN.
+func (urn *URN) String() string {
+	return "urn:" + urn.NID + ":" + urn.NSS
+}
+
+// UnmarshalURN takes a string payload and a pointer to a URN struct.
+// It parses the string representation of a URN into the URN struct.
+// It returns a *URIError that wraps ErrInvalidURN if the URN is invalid or the pointer is nil.
+func UnmarshalURN(payload string, urn *URN) error {
+	if urn == nil {
+		return &URIError{URI: payload, Reason: "nil destination", Err: ErrInvalidURN}
+	}
+
+	fields := strings.SplitN(payload, ":", 3)
+
+	if len(fields) != 3 || !strings.EqualFold(fields[0], "urn") {
+		return &URIError{URI: payload, Reason: "scheme is not urn", Err: ErrInvalidURN}
+	}
+
+	if !isNID(fields[1]) {
+		return &URIError{URI: payload, Reason: fmt.Sprintf("invalid namespace identifier %q", fields[1]), Err: ErrInvalidURN}
+	}
+
+	if !isNSS(fields[2]) {
+		return &URIError{URI: payload, Reason: fmt.Sprintf("invalid namespace specific string %q", fields[2]), Err: ErrInvalidURN}
+	}
+
+	urn.NID = fields[1]
+	urn.NSS = fields[2]
+
+	return nil
+}
+
+// MarshalURN takes a URN struct and converts it to its string representation.
+// It returns an error if the URN is invalid or nil.
+func MarshalURN(urn *URN) (string, error) {
+	if urn == nil || !isNID(urn.NID) || !isNSS(urn.NSS) {
+		return "", ErrInvalidURN
+	}
+
+	return urn.String(), nil
+}
+
+// isNID reports whether value is a namespace identifier, made of 2 to 32 alphanumeric characters and "-", that
+// neither starts nor ends with "-".
+func isNID(value string) bool {
+	if len(value) < 2 || len(value) > 32 || strings.HasPrefix(value, "-") || strings.HasSuffix(value, "-") {
+		return false
+	}
+
+	return isParameterName(value)
+}
+
+// isNSS reports whether value is a namespace specific string, optionally followed by its r-, q- and f-components,
+// that only has the characters of a URI with well-formed escapes.
+func isNSS(value string) bool {
+	if value == "" || strings.HasPrefix(value, "/") {
+		return false
+	}
+
+	for index := 0; index < len(value); index++ {
+		char := value[index]
+
+		switch {
+		case isUnreserved(char):
+		case strings.IndexByte(":@!$&'()*+,;=/?#", char) >= 0:
+		case char == '%' && index+2 < len(value) && grammar.IsHex(value[index+1]) && grammar.IsHex(value[index+2]):
+			index += 2
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,67 @@
+package uri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalURNWithValidCases(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    *URN
+	}{
+		{payload: "urn:service:sos", want: &URN{NID: "service", NSS: "sos"}},
+		{payload: "URN:service:sos.ambulance", want: &URN{NID: "service", NSS: "sos.ambulance"}},
+		{payload: "urn:ietf:params:xml:ns:pidf", want: &URN{NID: "ietf", NSS: "params:xml:ns:pidf"}},
+		{payload: "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", want: &URN{NID: "uuid", NSS: "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"}},
+		{payload: "urn:example:a%2Fb?+r?=q#f", want: &URN{NID: "example", NSS: "a%2Fb?+r?=q#f"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.payload, func(t *testing.T) {
+			got := new(URN)
+
+			assert.NoError(t, UnmarshalURN(tc.payload, got))
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, "urn", got.SchemeName())
+		})
+	}
+}
+
+func TestUnmarshalURNWithInvalidCases(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		urn     *URN
+	}{
+		{name: "Test without URN instance", payload: "urn:service:sos"},
+		{name: "Test other scheme", payload: "sip:service:sos", urn: new(URN)},
+		{name: "Test missing namespace specific string", payload: "urn:service", urn: new(URN)},
+		{name: "Test empty namespace specific string", payload: "urn:service:", urn: new(URN)},
+		{name: "Test one character namespace identifier", payload: "urn:x:sos", urn: new(URN)},
+		{name: "Test namespace identifier ending with hyphen", payload: "urn:service-:sos", urn: new(URN)},
+		{name: "Test namespace identifier with underscore", payload: "urn:ser_vice:sos", urn: new(URN)},
+		{name: "Test invalid escape", payload: "urn:service:s%zzos", urn: new(URN)},
+		{name: "Test space in namespace specific string", payload: "urn:service:s os", urn: new(URN)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, UnmarshalURN(tc.payload, tc.urn), ErrInvalidURN)
+		})
+	}
+}
+
+func TestMarshalURN(t *testing.T) {
+	got, err := MarshalURN(&URN{NID: "service", NSS: "sos.police"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:service:sos.police", got)
+
+	for _, urn := range []*URN{nil, {NID: "service"}, {NID: "-", NSS: "sos"}} {
+		_, err := MarshalURN(urn)
+
+		assert.ErrorIs(t, err, ErrInvalidURN)
+	}
+}